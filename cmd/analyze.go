@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/raesene/eolas/pkg/kubernetes"
 	"github.com/raesene/eolas/pkg/output"
@@ -23,15 +25,112 @@ var (
 	capabilityAnalysisFlag    bool
 	hostNamespaceAnalysisFlag bool
 	hostPathAnalysisFlag      bool
+	pssAnalysisFlag           bool
+	rbacAnalysisFlag          bool
+	networkPolicyAnalysisFlag bool
+	exposureAnalysisFlag      bool
+	imagesAnalysisFlag        bool
+	resourcesAnalysisFlag     bool
+	deprecatedAPIsFlag        bool
+	reliabilityAnalysisFlag   bool
+	referencesAnalysisFlag    bool
+	secretsAnalysisFlag       bool
+	serviceAccountsFlag       bool
+	webhooksAnalysisFlag      bool
+	schedulingAnalysisFlag    bool
+	analyzeAllowedRegistries  []string
+	analyzeTargetVersion      string
+	analyzeHostPathCatalogue  string
 	htmlOutputFlag            bool
 	outputFileFlag            string
+	analyzeChecks             []string
+	listChecksFlag            bool
 )
 
+// checkFlags maps the per-check analyze flags to the analyzer each one enables
+var checkFlags = []struct {
+	enabled *bool
+	id      string
+}{
+	{&privilegedAnalysisFlag, kubernetes.CheckPrivileged},
+	{&capabilityAnalysisFlag, kubernetes.CheckCapabilities},
+	{&hostNamespaceAnalysisFlag, kubernetes.CheckHostNamespaces},
+	{&hostPathAnalysisFlag, kubernetes.CheckHostPath},
+	{&pssAnalysisFlag, kubernetes.CheckPSS},
+	{&rbacAnalysisFlag, kubernetes.CheckRBAC},
+	{&networkPolicyAnalysisFlag, kubernetes.CheckNetworkPolicy},
+	{&exposureAnalysisFlag, kubernetes.CheckExposure},
+	{&imagesAnalysisFlag, kubernetes.CheckImages},
+	{&resourcesAnalysisFlag, kubernetes.CheckResources},
+	{&deprecatedAPIsFlag, kubernetes.CheckDeprecatedAPIs},
+	{&reliabilityAnalysisFlag, kubernetes.CheckReliability},
+	{&referencesAnalysisFlag, kubernetes.CheckReferences},
+	{&secretsAnalysisFlag, kubernetes.CheckSecrets},
+	{&serviceAccountsFlag, kubernetes.CheckServiceAccounts},
+	{&webhooksAnalysisFlag, kubernetes.CheckWebhooks},
+	{&schedulingAnalysisFlag, kubernetes.CheckScheduling},
+}
+
+// detailedViews holds the text output for checks that have a dedicated view.
+// Other registered checks are shown with the generic findings table.
+var detailedViews = map[string]func(config *kubernetes.ClusterConfig, opts kubernetes.Options){
+	kubernetes.CheckPrivileged: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showPrivilegedContainersText(kubernetes.GetPrivilegedContainers(config))
+	},
+	kubernetes.CheckCapabilities: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showCapabilityContainersText(kubernetes.GetCapabilityContainers(config))
+	},
+	kubernetes.CheckHostNamespaces: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showHostNamespaceWorkloadsText(kubernetes.GetHostNamespaceWorkloads(config))
+	},
+	kubernetes.CheckHostPath: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showHostPathVolumesText(kubernetes.GetHostPathVolumes(config, opts))
+	},
+	kubernetes.CheckPSS: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showPodSecurityStandardsText(kubernetes.EvaluatePodSecurityStandards(config))
+	},
+	kubernetes.CheckRBAC: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showRBACPermissionsText(kubernetes.GetRBACPermissions(config))
+	},
+	kubernetes.CheckNetworkPolicy: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showNetworkPoliciesText(kubernetes.AnalyzeNetworkPolicies(config))
+	},
+	kubernetes.CheckExposure: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showExposureText(kubernetes.GetExposedServices(config, opts))
+	},
+	kubernetes.CheckImages: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		fmt.Print(formatImagesText(kubernetes.GetImageInventory(config, opts)))
+	},
+	kubernetes.CheckResources: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showResourcesText(kubernetes.AnalyzeResources(config))
+	},
+	kubernetes.CheckDeprecatedAPIs: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showDeprecatedAPIsText(kubernetes.GetDeprecatedAPIs(config, opts), opts.TargetVersion)
+	},
+	kubernetes.CheckReliability: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showReliabilityText(kubernetes.AnalyzeReliability(config))
+	},
+	kubernetes.CheckServiceAccounts: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showServiceAccountTokensText(kubernetes.GetServiceAccountTokens(config))
+	},
+	kubernetes.CheckWebhooks: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showAdmissionWebhooksText(kubernetes.GetAdmissionWebhooks(config, opts))
+	},
+	kubernetes.CheckScheduling: func(config *kubernetes.ClusterConfig, opts kubernetes.Options) {
+		showSchedulingEscapesText(kubernetes.GetSchedulingEscapes(config))
+	},
+}
+
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze",
 	Short: "Analyze a stored Kubernetes cluster configuration",
 	Long:  `Analyze a stored Kubernetes cluster configuration to extract useful information.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if listChecksFlag {
+			showAvailableChecks()
+			return
+		}
+
 		if analyzeClusterName == "" {
 			fmt.Println("Error: cluster name is required")
 			cmd.Help()
@@ -44,6 +143,17 @@ var analyzeCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		checks, err := selectedChecks()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts, err := analyzerOptions(analyzeAllowedRegistries, analyzeTargetVersion, analyzeHostPathCatalogue)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 		// Determine storage directory
 		var storeDir string
 		if analyzeStorageDir != "" {
@@ -82,32 +192,12 @@ var analyzeCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "Error loading configuration '%s': %v\n", analyzeClusterName, err)
 			os.Exit(1)
 		}
+		fingerprint := storedFingerprint(store, analyzeClusterName, config)
+		opts.Now = snapshotTime(store, analyzeClusterName)
 
 		// Get resource counts for all analysis types
 		resourceCounts := kubernetes.GetResourceCounts(config)
 		
-		// Collect security analysis data if needed for any output format
-		var privilegedContainers []kubernetes.PrivilegedContainer
-		var capabilityContainers []kubernetes.CapabilityContainer
-		var hostNamespaceWorkloads []kubernetes.HostNamespaceWorkload
-		var hostPathVolumes []kubernetes.HostPathVolume
-		
-		if securityAnalysisFlag || privilegedAnalysisFlag || htmlOutputFlag {
-			privilegedContainers = kubernetes.GetPrivilegedContainers(config)
-		}
-		
-		if securityAnalysisFlag || capabilityAnalysisFlag || htmlOutputFlag {
-			capabilityContainers = kubernetes.GetCapabilityContainers(config)
-		}
-		
-		if securityAnalysisFlag || hostNamespaceAnalysisFlag || htmlOutputFlag {
-			hostNamespaceWorkloads = kubernetes.GetHostNamespaceWorkloads(config)
-		}
-		
-		if securityAnalysisFlag || hostPathAnalysisFlag || htmlOutputFlag {
-			hostPathVolumes = kubernetes.GetHostPathVolumes(config)
-		}
-		
 		// Handle HTML output if requested
 		if htmlOutputFlag {
 			htmlFormatter, err := output.NewHTMLFormatter()
@@ -116,14 +206,30 @@ var analyzeCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			
-			htmlContent, err := htmlFormatter.GenerateHTML(
-				analyzeClusterName,
-				resourceCounts,
-				privilegedContainers,
-				capabilityContainers,
-				hostNamespaceWorkloads,
-				hostPathVolumes,
-			)
+			findings := kubernetes.RunAnalyzers(config, opts)
+			htmlContent, err := htmlFormatter.GenerateHTML(output.HTMLData{
+				ClusterName:       analyzeClusterName,
+				Fingerprint:       fingerprint,
+				ResourceCounts:    resourceCounts,
+				PrivilegedResults: kubernetes.GetPrivilegedContainers(config),
+				CapabilityResults: kubernetes.GetCapabilityContainers(config),
+				HostNSResults:     kubernetes.GetHostNamespaceWorkloads(config),
+				HostPathResults:   kubernetes.GetHostPathVolumes(config, opts),
+				PSSResults:        kubernetes.EvaluatePodSecurityStandards(config),
+				RBACResults:       kubernetes.GetRBACPermissions(config),
+				NetworkPolicies:   kubernetes.AnalyzeNetworkPolicies(config),
+				ExposureResults:   kubernetes.GetExposedServices(config, opts),
+				ResourceResults:   kubernetes.AnalyzeResources(config),
+				CapacityResults:   kubernetes.GetCapacityReport(config),
+				DeprecatedAPIs:    kubernetes.GetDeprecatedAPIs(config, opts),
+				TargetVersion:     analyzeTargetVersion,
+				Reliability:       kubernetes.AnalyzeReliability(config),
+				ServiceAccounts:   kubernetes.GetServiceAccountTokens(config),
+				Webhooks:          kubernetes.GetAdmissionWebhooks(config, opts),
+				Scheduling:        kubernetes.GetSchedulingEscapes(config),
+				Checks:            output.NewCheckResults(findings),
+				Risk:              kubernetes.ScoreFindings(findings),
+			})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating HTML: %v\n", err)
 				os.Exit(1)
@@ -150,42 +256,132 @@ var analyzeCmd = &cobra.Command{
 		}
 		
 		// Standard text output (original functionality)
-		fmt.Printf("Analyzing cluster configuration: %s\n\n", analyzeClusterName)
+		fmt.Printf("Analyzing cluster configuration: %s\n", analyzeClusterName)
+		showFingerprint(fingerprint)
+		fmt.Println()
 
 		// Standard resource analysis
-		if !securityAnalysisFlag && !privilegedAnalysisFlag && !capabilityAnalysisFlag && !hostNamespaceAnalysisFlag {
+		if len(checks) == 0 {
 			showResourceAnalysis(config)
+			return
 		}
 
 		// Security analysis
-		if securityAnalysisFlag || privilegedAnalysisFlag || capabilityAnalysisFlag || hostNamespaceAnalysisFlag || hostPathAnalysisFlag {
-			// If any security flag is enabled, show security analysis header
-			fmt.Println("Security Analysis:")
-			fmt.Println("=================")
-
-			// Privileged container analysis
-			if securityAnalysisFlag || privilegedAnalysisFlag {
-				showPrivilegedContainersText(privilegedContainers)
-			}
-			
-			// Capability analysis
-			if securityAnalysisFlag || capabilityAnalysisFlag {
-				showCapabilityContainersText(capabilityContainers)
-			}
-			
-			// Host namespace analysis
-			if securityAnalysisFlag || hostNamespaceAnalysisFlag {
-				showHostNamespaceWorkloadsText(hostNamespaceWorkloads)
-			}
-			
-			// Host path volume analysis
-			if securityAnalysisFlag || hostPathAnalysisFlag {
-				showHostPathVolumesText(hostPathVolumes)
+		fmt.Println("Security Analysis:")
+		fmt.Println("=================")
+
+		for _, analyzer := range checks {
+			if view, ok := detailedViews[analyzer.ID()]; ok {
+				view(config, opts)
+			} else {
+				showFindingsText(analyzer, kubernetes.RunAnalyzer(analyzer, config, opts))
 			}
 		}
+		
+		// The risk score covers every check, so it is only shown for a full analysis
+		if securityAnalysisFlag {
+			showRiskText(kubernetes.ScoreFindings(kubernetes.RunAnalyzers(config, opts)))
+		}
 	},
 }
 
+// analyzerOptions builds the analyzer options from a command's --allowed-registries,
+// --target-version and --host-path-catalogue flags
+func analyzerOptions(allowedRegistries []string, targetVersion, hostPathCatalogue string) (kubernetes.Options, error) {
+	opts := kubernetes.Options{
+		AllowedRegistries: allowedRegistries,
+		TargetVersion:     targetVersion,
+	}
+	if targetVersion != "" {
+		if _, err := kubernetes.ParseKubernetesVersion(targetVersion); err != nil {
+			return opts, err
+		}
+	}
+	if hostPathCatalogue != "" {
+		catalogue, err := kubernetes.LoadHostPathCatalogue(hostPathCatalogue)
+		if err != nil {
+			return opts, err
+		}
+		opts.HostPathCatalogue = catalogue
+	}
+	return opts, nil
+}
+
+// selectedChecks returns the analyzers requested with --security, --check and the
+// per-check flags, in registration order
+func selectedChecks() ([]kubernetes.Analyzer, error) {
+	requested := make(map[string]bool)
+
+	for _, id := range analyzeChecks {
+		if _, ok := kubernetes.GetAnalyzer(id); !ok {
+			return nil, fmt.Errorf("unknown check '%s'. Available checks are: %s", id, strings.Join(kubernetes.AnalyzerIDs(), ", "))
+		}
+		requested[id] = true
+	}
+
+	for _, flag := range checkFlags {
+		if *flag.enabled {
+			requested[flag.id] = true
+		}
+	}
+
+	var checks []kubernetes.Analyzer
+	for _, analyzer := range kubernetes.Analyzers() {
+		if securityAnalysisFlag || requested[analyzer.ID()] {
+			checks = append(checks, analyzer)
+		}
+	}
+
+	return checks, nil
+}
+
+// showAvailableChecks lists the registered analyzers
+func showAvailableChecks() {
+	fmt.Println("Available Checks:")
+	fmt.Println("================")
+	fmt.Printf("%-20s %-10s %s\n", "ID", "SEVERITY", "NAME")
+	fmt.Printf("%-20s %-10s %s\n", "--", "--------", "----")
+
+	for _, analyzer := range kubernetes.Analyzers() {
+		fmt.Printf("%-20s %-10s %s\n", analyzer.ID(), analyzer.Severity(), analyzer.Name())
+	}
+
+	fmt.Println()
+	fmt.Println("Run a check with: eolas analyze -n <config-name> --check <id>")
+}
+
+// showFindingsText displays the findings of an analyzer that has no dedicated view (text output)
+func showFindingsText(analyzer kubernetes.Analyzer, findings []kubernetes.Finding) {
+	fmt.Printf("%s:\n", analyzer.Name())
+	fmt.Println(strings.Repeat("=", len(analyzer.Name())+1))
+
+	if len(findings) == 0 {
+		fmt.Printf("No findings for %s in the cluster.\n", strings.ToLower(analyzer.Name()))
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("Found %d findings\n\n", len(findings))
+	fmt.Printf("%-10s %-20s %-15s %-20s %-15s %s\n", "SEVERITY", "NAMESPACE", "RESOURCE TYPE", "RESOURCE NAME", "CONTAINER", "DETAILS")
+	fmt.Printf("%-10s %-20s %-15s %-20s %-15s %s\n", "--------", "---------", "------------", "------------", "---------", "-------")
+
+	for _, f := range findings {
+		namespace := f.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		container := f.Container
+		if container == "" {
+			container = "-"
+		}
+		fmt.Printf("%-10s %-20s %-15s %-20s %-15s %s\n", f.Severity, namespace, f.Kind, f.Name, container, f.Details)
+	}
+
+	fmt.Println()
+	fmt.Printf("Note: %s\n", analyzer.Description())
+	fmt.Println()
+}
+
 // showResourceAnalysis displays standard resource counts
 func showResourceAnalysis(config *kubernetes.ClusterConfig) {
 	// Get resource counts
@@ -224,21 +420,23 @@ func showPrivilegedContainersText(privilegedContainers []kubernetes.PrivilegedCo
 	}
 	
 	fmt.Printf("Found %d privileged containers\n\n", len(privilegedContainers))
-	fmt.Printf("%-20s %-20s %-20s %-30s\n", "NAMESPACE", "RESOURCE TYPE", "RESOURCE NAME", "CONTAINER NAME")
-	fmt.Printf("%-20s %-20s %-20s %-30s\n", "---------", "------------", "------------", "--------------")
+	fmt.Printf("%-20s %-20s %-20s %-30s %-10s\n", "NAMESPACE", "RESOURCE TYPE", "RESOURCE NAME", "CONTAINER NAME", "TYPE")
+	fmt.Printf("%-20s %-20s %-20s %-30s %-10s\n", "---------", "------------", "------------", "--------------", "----")
 	
 	for _, pc := range privilegedContainers {
 		namespace := pc.Namespace
 		if namespace == "" {
 			namespace = "default"
 		}
-		fmt.Printf("%-20s %-20s %-20s %-30s\n", namespace, pc.Kind, pc.PodName, pc.Name)
+		fmt.Printf("%-20s %-20s %-20s %-30s %-10s\n", namespace, pc.Kind, pc.PodName, pc.Name, pc.Type)
+		showSecuritySettings(pc.Settings)
 	}
 	
 	fmt.Println()
 	fmt.Println("Note: Privileged containers have full access to the host's kernel capabilities and")
 	fmt.Println("device nodes, similar to root access on the host. These should be reviewed carefully")
-	fmt.Println("for security implications.")
+	fmt.Println("for security implications. Ephemeral containers added with kubectl debug are included;")
+	fmt.Println("settings show the effective security context and the level (container, pod) that set them.")
 	fmt.Println()
 }
 
@@ -255,8 +453,8 @@ func showCapabilityContainersText(capContainers []kubernetes.CapabilityContainer
 	}
 	
 	fmt.Printf("Found %d containers with added Linux capabilities\n\n", len(capContainers))
-	fmt.Printf("%-20s %-15s %-20s %-15s %-30s\n", "NAMESPACE", "RESOURCE TYPE", "RESOURCE NAME", "CONTAINER", "CAPABILITIES")
-	fmt.Printf("%-20s %-15s %-20s %-15s %-30s\n", "---------", "------------", "------------", "---------", "------------")
+	fmt.Printf("%-20s %-15s %-20s %-15s %-10s %-30s\n", "NAMESPACE", "RESOURCE TYPE", "RESOURCE NAME", "CONTAINER", "TYPE", "CAPABILITIES")
+	fmt.Printf("%-20s %-15s %-20s %-15s %-10s %-30s\n", "---------", "------------", "------------", "---------", "----", "------------")
 	
 	for _, cc := range capContainers {
 		namespace := cc.Namespace
@@ -273,7 +471,8 @@ func showCapabilityContainersText(capContainers []kubernetes.CapabilityContainer
 			capsStr = joinStrings(caps[:3], ", ") + ", +" + fmt.Sprintf("%d", len(caps)-3) + " more"
 		}
 		
-		fmt.Printf("%-20s %-15s %-20s %-15s %-30s\n", namespace, cc.Kind, cc.PodName, cc.Name, capsStr)
+		fmt.Printf("%-20s %-15s %-20s %-15s %-10s %-30s\n", namespace, cc.Kind, cc.PodName, cc.Name, cc.Type, capsStr)
+		showSecuritySettings(cc.Settings)
 	}
 	
 	fmt.Println()
@@ -283,6 +482,19 @@ func showCapabilityContainersText(capContainers []kubernetes.CapabilityContainer
 	fmt.Println()
 }
 
+// showSecuritySettings lists the risky settings of a container's effective security context
+// under its row
+func showSecuritySettings(settings []kubernetes.SecuritySetting) {
+	if len(settings) == 0 {
+		return
+	}
+	parts := make([]string, len(settings))
+	for i, s := range settings {
+		parts[i] = s.String()
+	}
+	fmt.Printf("  effective: %s\n", joinStrings(parts, ", "))
+}
+
 // joinStrings joins string slice with separator
 func joinStrings(strs []string, sep string) string {
 	if len(strs) == 0 {
@@ -378,12 +590,12 @@ func showHostPathVolumesText(volumes []kubernetes.HostPathVolume) {
 	fmt.Printf("Found %d workloads using hostPath volumes\n\n", len(volumes))
 	
 	// Print table header
-	fmt.Printf("%-20s %-15s %-20s %-15s %s\n", 
-		"NAMESPACE", "RESOURCE TYPE", "NAME", "READ-ONLY", "HOST PATH")
-	fmt.Printf("%-20s %-15s %-20s %-15s %s\n", 
-		"---------", "------------", "----", "---------", "---------")
+	fmt.Printf("%-20s %-15s %-20s %-10s %-10s %-30s %s\n", 
+		"NAMESPACE", "RESOURCE TYPE", "NAME", "SEVERITY", "READ-ONLY", "HOST PATH", "REASON")
+	fmt.Printf("%-20s %-15s %-20s %-10s %-10s %-30s %s\n", 
+		"---------", "------------", "----", "--------", "---------", "---------", "------")
 	
-	// Print each workload with their host paths
+	// Print each workload with their host paths, most dangerous first
 	for _, v := range volumes {
 		namespace := v.Namespace
 		if namespace == "" {
@@ -399,12 +611,12 @@ func showHostPathVolumesText(volumes []kubernetes.HostPathVolume) {
 			
 			// For the first path, include the workload details
 			if i == 0 {
-				fmt.Printf("%-20s %-15s %-20s %-15s %s\n", 
-					namespace, v.Kind, v.Name, readOnly, path)
+				fmt.Printf("%-20s %-15s %-20s %-10s %-10s %-30s %s\n", 
+					namespace, v.Kind, v.Name, v.Severities[i], readOnly, path, v.Reasons[i])
 			} else {
 				// For subsequent paths, just include the path and read-only status
-				fmt.Printf("%-20s %-15s %-20s %-15s %s\n", 
-					"", "", "", readOnly, path)
+				fmt.Printf("%-20s %-15s %-20s %-10s %-10s %-30s %s\n", 
+					"", "", "", v.Severities[i], readOnly, path, v.Reasons[i])
 			}
 		}
 	}
@@ -418,9 +630,645 @@ func showHostPathVolumesText(volumes []kubernetes.HostPathVolume) {
 	fmt.Println("- Potential modification of host system files (when not read-only)")
 	fmt.Println("- Persistence across pod restarts, potentially allowing data exfiltration")
 	fmt.Println("- Potential for privilege escalation through the host filesystem")
+	fmt.Println("Severities come from the sensitive host path catalogue; override or extend it with")
+	fmt.Println("--host-path-catalogue.")
+	fmt.Println()
+}
+
+// showPodSecurityStandardsText displays Pod Security Standards results (text output)
+func showPodSecurityStandardsText(result kubernetes.PSSResult) {
+	fmt.Println("Pod Security Standards:")
+	fmt.Println("======================")
+	
+	if len(result.Namespaces) == 0 {
+		fmt.Println("No namespaces or workloads found in the cluster.")
+		fmt.Println()
+		return
+	}
+	
+	fmt.Println("Highest profile each namespace could enforce:")
+	fmt.Println()
+	fmt.Printf("%-25s %-10s %-15s %-15s %-10s %s\n", 
+		"NAMESPACE", "WORKLOADS", "CURRENT", "ENFORCEABLE", "BASELINE", "RESTRICTED")
+	fmt.Printf("%-25s %-10s %-15s %-15s %-10s %s\n", 
+		"---------", "---------", "-------", "-----------", "--------", "----------")
+	
+	for _, ns := range result.Namespaces {
+		namespace := ns.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		current := ns.CurrentEnforce
+		if current == "" {
+			current = "-"
+		}
+		fmt.Printf("%-25s %-10d %-15s %-15s %-10d %d\n", 
+			namespace, ns.Workloads, current, ns.Level, ns.BaselineViolations, ns.RestrictedViolations)
+	}
+	fmt.Println()
+	
+	violating := 0
+	for _, w := range result.Workloads {
+		if len(w.Violations) > 0 {
+			violating++
+		}
+	}
+	
+	if violating == 0 {
+		fmt.Println("All workloads meet the restricted profile.")
+	} else {
+		fmt.Printf("Found %d workloads that do not meet the restricted profile\n\n", violating)
+		fmt.Printf("%-20s %-15s %-20s %-12s %-15s %-25s %s\n", 
+			"NAMESPACE", "RESOURCE TYPE", "NAME", "PROFILE", "CONTAINER", "CHECK", "DETAILS")
+		fmt.Printf("%-20s %-15s %-20s %-12s %-15s %-25s %s\n", 
+			"---------", "------------", "----", "-------", "---------", "-----", "-------")
+		
+		for _, w := range result.Workloads {
+			namespace := w.Namespace
+			if namespace == "" {
+				namespace = "default"
+			}
+			
+			for i, v := range w.Violations {
+				container := v.Container
+				if container == "" {
+					container = "-"
+				}
+				
+				// For the first violation, include the workload details
+				if i == 0 {
+					fmt.Printf("%-20s %-15s %-20s %-12s %-15s %-25s %s\n", 
+						namespace, w.Kind, w.Name, v.Level, container, v.Check, v.Detail)
+				} else {
+					fmt.Printf("%-20s %-15s %-20s %-12s %-15s %-25s %s\n", 
+						"", "", "", v.Level, container, v.Check, v.Detail)
+				}
+			}
+		}
+	}
+	
+	fmt.Println()
+	fmt.Println("Note: The PROFILE column shows the lowest profile that rejects each setting. A namespace")
+	fmt.Println("can enforce its ENFORCEABLE profile without rejecting any current workload, e.g.:")
+	fmt.Println("  kubectl label namespace <namespace> pod-security.kubernetes.io/enforce=<profile>")
 	fmt.Println()
 }
 
+// showRBACPermissionsText displays the effective RBAC permissions of each subject (text output)
+func showRBACPermissionsText(subjects []kubernetes.SubjectPermissions) {
+	fmt.Println("RBAC Privileges:")
+	fmt.Println("===============")
+	
+	if len(subjects) == 0 {
+		fmt.Println("No role bindings found in the cluster.")
+		fmt.Println()
+		return
+	}
+	
+	risky := 0
+	for _, s := range subjects {
+		if len(s.Risks) > 0 {
+			risky++
+		}
+	}
+	fmt.Printf("Found %d subjects, %d with dangerous permissions\n\n", len(subjects), risky)
+	
+	// Effective permission summary per subject
+	for _, s := range subjects {
+		name := s.Name
+		if s.Namespace != "" {
+			name = s.Namespace + "/" + s.Name
+		}
+		header := fmt.Sprintf("%s %s", s.Kind, name)
+		if s.ClusterAdmin {
+			header += " [CLUSTER ADMIN]"
+		}
+		fmt.Println(header)
+		
+		for _, g := range s.Grants {
+			scope := "cluster-wide"
+			if g.Namespace != "" {
+				scope = "namespace " + g.Namespace
+			}
+			fmt.Printf("  %s -> %s (%s)\n", g.Binding, g.Role, scope)
+			if g.Missing {
+				fmt.Println("    role not found in configuration")
+				continue
+			}
+			for _, rule := range g.Rules {
+				fmt.Printf("    %s\n", kubernetes.FormatRule(rule))
+			}
+		}
+		fmt.Println()
+	}
+	
+	if risky > 0 {
+		fmt.Printf("%-10s %-15s %-35s %-20s %-20s %s\n", "SEVERITY", "SUBJECT TYPE", "SUBJECT", "CHECK", "SCOPE", "DETAILS")
+		fmt.Printf("%-10s %-15s %-35s %-20s %-20s %s\n", "--------", "------------", "-------", "-----", "-----", "-------")
+		
+		for _, s := range subjects {
+			name := s.Name
+			if s.Namespace != "" {
+				name = s.Namespace + "/" + s.Name
+			}
+			for _, r := range s.Risks {
+				scope := "cluster"
+				if r.Namespace != "" {
+					scope = r.Namespace
+				}
+				fmt.Printf("%-10s %-15s %-35s %-20s %-20s %s (%s)\n", r.Severity, s.Kind, name, r.Check, scope, r.Detail, r.Binding)
+			}
+		}
+		fmt.Println()
+	}
+	
+	fmt.Println("Note: Default bindings created by the API server (labelled kubernetes.io/bootstrapping)")
+	fmt.Println("are not shown. Permissions such as escalate, bind, impersonate, pods/exec, secrets read")
+	fmt.Println("and nodes/proxy can each be used to gain further access to the cluster.")
+	fmt.Println()
+}
+
+// showNetworkPoliciesText displays NetworkPolicy coverage (text output)
+func showNetworkPoliciesText(result kubernetes.NetworkPolicyResult) {
+	fmt.Println("Network Policy Coverage:")
+	fmt.Println("=======================")
+	
+	if len(result.Namespaces) == 0 {
+		fmt.Println("No namespaces or workloads found in the cluster.")
+		fmt.Println()
+		return
+	}
+	
+	fmt.Printf("%-25s %-10s %-10s %-20s %s\n", "NAMESPACE", "POLICIES", "WORKLOADS", "DEFAULT-DENY INGRESS", "DEFAULT-DENY EGRESS")
+	fmt.Printf("%-25s %-10s %-10s %-20s %s\n", "---------", "--------", "---------", "--------------------", "-------------------")
+	for _, ns := range result.Namespaces {
+		fmt.Printf("%-25s %-10d %-10d %-20t %t\n", ns.Namespace, ns.Policies, ns.Workloads, ns.DefaultDenyIngress, ns.DefaultDenyEgress)
+	}
+	fmt.Println()
+	
+	if len(result.UnselectedWorkloads) > 0 {
+		fmt.Printf("Found %d workloads not selected by any NetworkPolicy\n\n", len(result.UnselectedWorkloads))
+		fmt.Printf("%-20s %-15s %s\n", "NAMESPACE", "RESOURCE TYPE", "NAME")
+		fmt.Printf("%-20s %-15s %s\n", "---------", "------------", "----")
+		for _, w := range result.UnselectedWorkloads {
+			fmt.Printf("%-20s %-15s %s\n", w.Namespace, w.Kind, w.Name)
+		}
+		fmt.Println()
+	}
+	
+	if len(result.OpenWorkloads) > 0 {
+		fmt.Printf("Found %d workloads reachable from all namespaces\n\n", len(result.OpenWorkloads))
+		fmt.Printf("%-20s %-15s %-20s %s\n", "NAMESPACE", "RESOURCE TYPE", "NAME", "POLICIES")
+		fmt.Printf("%-20s %-15s %-20s %s\n", "---------", "------------", "----", "--------")
+		for _, w := range result.OpenWorkloads {
+			fmt.Printf("%-20s %-15s %-20s %s\n", w.Namespace, w.Kind, w.Name, strings.Join(w.Policies, ", "))
+		}
+		fmt.Println()
+	}
+	
+	if len(result.UnusedPolicies) > 0 {
+		fmt.Printf("Found %d policies whose pod selector matches no workload\n\n", len(result.UnusedPolicies))
+		fmt.Printf("%-20s %s\n", "NAMESPACE", "POLICY")
+		fmt.Printf("%-20s %s\n", "---------", "------")
+		for _, p := range result.UnusedPolicies {
+			fmt.Printf("%-20s %s\n", p.Namespace, p.Name)
+		}
+		fmt.Println()
+	}
+	
+	fmt.Println("Note: Pods not selected by any NetworkPolicy accept traffic from every pod in the cluster,")
+	fmt.Println("which allows lateral movement after a single workload is compromised. A policy with an empty")
+	fmt.Println("podSelector and no rules denies all ingress or egress traffic in its namespace by default.")
+	fmt.Println()
+}
+
+// showExposureText displays entry points reachable from outside the cluster (text output)
+func showExposureText(entries []kubernetes.ExposureEntry) {
+	fmt.Println("External Exposure:")
+	fmt.Println("=================")
+	
+	if len(entries) == 0 {
+		fmt.Println("No NodePort, LoadBalancer or externalIPs Services, Ingresses or HTTPRoutes found in the cluster.")
+		fmt.Println()
+		return
+	}
+	
+	fmt.Printf("Found %d externally reachable entry points\n\n", len(entries))
+	fmt.Printf("%-20s %-12s %-25s %-13s %-5s %-30s %s\n", "NAMESPACE", "KIND", "NAME", "TYPE", "TLS", "HOSTS / PORTS", "WORKLOADS")
+	fmt.Printf("%-20s %-12s %-25s %-13s %-5s %-30s %s\n", "---------", "----", "----", "----", "---", "-------------", "---------")
+	
+	for _, e := range entries {
+		tls := "-"
+		if e.Type == "Ingress" || e.Type == "HTTPRoute" {
+			tls = "No"
+			if e.TLS {
+				tls = "Yes"
+			}
+		}
+		
+		endpoints := append(append([]string{}, e.Hosts...), e.Ports...)
+		if len(endpoints) == 0 {
+			endpoints = []string{"-"}
+		}
+		
+		var workloads []string
+		for _, w := range e.Workloads {
+			text := w.Kind + "/" + w.Name
+			if len(w.Findings) > 0 {
+				text += " [" + strings.Join(w.Findings, ", ") + "]"
+			}
+			workloads = append(workloads, text)
+		}
+		if len(workloads) == 0 {
+			workloads = []string{"-"}
+		}
+		
+		fmt.Printf("%-20s %-12s %-25s %-13s %-5s %-30s %s\n", e.Namespace, e.Kind, e.Name, e.Type, tls, strings.Join(endpoints, ", "), strings.Join(workloads, ", "))
+	}
+	
+	fmt.Println()
+	fmt.Println("Note: Entry points are listed first when a backing workload also has privileged, capability,")
+	fmt.Println("host namespace or hostPath findings, as these are the highest priority to review.")
+	fmt.Println()
+}
+
+// showResourcesText displays resource requests/limits and QoS per namespace (text output)
+func showResourcesText(result kubernetes.ResourceResult) {
+	fmt.Println("Resource Requests and Limits:")
+	fmt.Println("============================")
+	
+	if len(result.Workloads) == 0 {
+		fmt.Println("No workloads found in the cluster.")
+		fmt.Println()
+		return
+	}
+	
+	fmt.Printf("%-20s %-10s %-11s %-12s %-11s %-12s %-11s %-10s %-11s %s\n",
+		"NAMESPACE", "WORKLOADS", "CONTAINERS", "NO REQUESTS", "NO LIMITS", "HIGH LIMITS", "GUARANTEED", "BURSTABLE", "BESTEFFORT", "LIMITRANGE/QUOTA")
+	fmt.Printf("%-20s %-10s %-11s %-12s %-11s %-12s %-11s %-10s %-11s %s\n",
+		"---------", "---------", "----------", "-----------", "---------", "-----------", "----------", "---------", "----------", "----------------")
+	for _, ns := range result.Namespaces {
+		fmt.Printf("%-20s %-10d %-11d %-12d %-11d %-12d %-11d %-10d %-11d %s/%s\n",
+			ns.Namespace, ns.Workloads, ns.Containers, ns.MissingRequests, ns.MissingLimits, ns.HighLimits,
+			ns.Guaranteed, ns.Burstable, ns.BestEffort, yesNo(ns.HasLimitRange), yesNo(ns.HasResourceQuota))
+	}
+	fmt.Println()
+	
+	var rows [][]string
+	for _, w := range result.Workloads {
+		for _, c := range w.Containers {
+			if len(c.Missing) == 0 && len(c.HighLimits) == 0 {
+				continue
+			}
+			issues := c.HighLimits
+			if len(c.Missing) > 0 {
+				issues = append([]string{"missing " + strings.Join(c.Missing, ", ")}, c.HighLimits...)
+			}
+			rows = append(rows, []string{w.Namespace, w.Kind, w.Name, c.Name, w.QoSClass, strings.Join(issues, "; ")})
+		}
+	}
+	
+	if len(rows) > 0 {
+		fmt.Printf("Found %d containers with resource issues\n\n", len(rows))
+		fmt.Printf("%-20s %-15s %-20s %-15s %-11s %s\n", "NAMESPACE", "RESOURCE TYPE", "NAME", "CONTAINER", "QOS", "ISSUES")
+		fmt.Printf("%-20s %-15s %-20s %-15s %-11s %s\n", "---------", "------------", "----", "---------", "---", "------")
+		for _, row := range rows {
+			fmt.Printf("%-20s %-15s %-20s %-15s %-11s %s\n", row[0], row[1], row[2], row[3], row[4], row[5])
+		}
+		fmt.Println()
+	}
+	
+	fmt.Printf("Note: Limits more than %.0fx their request are flagged. BestEffort pods are evicted first under\n", kubernetes.LimitRatioThreshold)
+	fmt.Println("node pressure, and a LimitRange or ResourceQuota sets defaults and caps for a namespace.")
+	fmt.Println()
+}
+
+// showDeprecatedAPIsText displays resources using deprecated or removed API versions (text output)
+func showDeprecatedAPIsText(deprecated []kubernetes.DeprecatedAPI, target string) {
+	fmt.Println("Deprecated APIs:")
+	fmt.Println("===============")
+	
+	if target == "" {
+		target = "any version (use --target-version to plan an upgrade)"
+	}
+	fmt.Printf("Target version: %s\n\n", target)
+	
+	if len(deprecated) == 0 {
+		fmt.Println("No resources using deprecated API versions found in the cluster.")
+		fmt.Println()
+		return
+	}
+	
+	removed := 0
+	for _, d := range deprecated {
+		if d.Removed {
+			removed++
+		}
+	}
+	fmt.Printf("Found %d resources using deprecated API versions, %d removed in the target version\n\n", len(deprecated), removed)
+	
+	fmt.Printf("%-20s %-25s %-30s %-40s %-10s %-10s %s\n", "NAMESPACE", "RESOURCE TYPE", "NAME", "API VERSION", "DEPRECATED", "REMOVED", "REPLACEMENT")
+	fmt.Printf("%-20s %-25s %-30s %-40s %-10s %-10s %s\n", "---------", "-------------", "----", "-----------", "----------", "-------", "-----------")
+	for _, d := range deprecated {
+		apiVersion := d.APIVersion
+		if d.Source != "object" {
+			apiVersion += " (applied)"
+		}
+		removedIn := d.RemovedIn
+		if d.Removed {
+			removedIn += " !"
+		}
+		fmt.Printf("%-20s %-25s %-30s %-40s %-10s %-10s %s\n", d.Namespace, d.Kind, d.Name, apiVersion, d.DeprecatedIn, removedIn, d.Replacement)
+	}
+	
+	fmt.Println()
+	fmt.Println("Note: APIs marked ! are no longer served by the target version. \"(applied)\" versions come from the")
+	fmt.Println("last-applied-configuration annotation: the manifests used to apply them must be updated before upgrading.")
+	fmt.Println()
+}
+
+// showReliabilityText displays workloads and PodDisruptionBudgets with reliability issues (text output)
+func showReliabilityText(result kubernetes.ReliabilityResult) {
+	fmt.Println("Reliability:")
+	fmt.Println("===========")
+	
+	if len(result.Workloads) == 0 && len(result.BlockingPDBs) == 0 {
+		fmt.Println("No reliability issues found in the cluster.")
+		fmt.Println()
+		return
+	}
+	
+	if len(result.Workloads) > 0 {
+		fmt.Printf("Found %d workloads with reliability issues\n\n", len(result.Workloads))
+		fmt.Printf("%-20s %-12s %-30s %-9s %-20s %s\n", "NAMESPACE", "KIND", "NAME", "REPLICAS", "PDBS", "ISSUES")
+		fmt.Printf("%-20s %-12s %-30s %-9s %-20s %s\n", "---------", "----", "----", "--------", "----", "------")
+		for _, w := range result.Workloads {
+			replicas := "-"
+			if w.Kind != "DaemonSet" {
+				replicas = fmt.Sprint(w.Replicas)
+			}
+			pdbs := strings.Join(w.PDBs, ",")
+			if pdbs == "" {
+				pdbs = "-"
+			}
+			fmt.Printf("%-20s %-12s %-30s %-9s %-20s %s\n", w.Namespace, w.Kind, w.Name, replicas, pdbs, strings.Join(w.Issues, ", "))
+		}
+		fmt.Println()
+	}
+	
+	if len(result.BlockingPDBs) > 0 {
+		fmt.Printf("Found %d PodDisruptionBudgets that block all evictions\n\n", len(result.BlockingPDBs))
+		fmt.Printf("%-20s %-30s %-35s %s\n", "NAMESPACE", "NAME", "REASON", "WORKLOADS")
+		fmt.Printf("%-20s %-30s %-35s %s\n", "---------", "----", "------", "---------")
+		for _, p := range result.BlockingPDBs {
+			workloads := strings.Join(p.Workloads, ", ")
+			if workloads == "" {
+				workloads = "-"
+			}
+			fmt.Printf("%-20s %-30s %-35s %s\n", p.Namespace, p.Name, p.Reason, workloads)
+		}
+		fmt.Println()
+	}
+	
+	fmt.Println("Note: A single replica without a PodDisruptionBudget goes down on every node drain, while a PDB")
+	fmt.Println("that allows no evictions stops drains and upgrades from completing.")
+	fmt.Println()
+}
+
+// showServiceAccountTokensText displays automounted tokens, token Secrets and workloads
+// running as the default service account (text output)
+func showServiceAccountTokensText(tokens []kubernetes.ServiceAccountToken) {
+	fmt.Println("Service Account Tokens:")
+	fmt.Println("======================")
+	
+	if len(tokens) == 0 {
+		fmt.Println("No service account token exposure found in the cluster.")
+		fmt.Println()
+		return
+	}
+	
+	fmt.Printf("Found %d service account token issues\n\n", len(tokens))
+	
+	fmt.Printf("%-20s %-12s %-30s %-20s %-24s %-16s %s\n",
+		"NAMESPACE", "KIND", "NAME", "SERVICE ACCOUNT", "ISSUE", "AUTOMOUNT", "BINDINGS")
+	fmt.Printf("%-20s %-12s %-30s %-20s %-24s %-16s %s\n",
+		"---------", "----", "----", "---------------", "-----", "---------", "--------")
+	
+	for _, t := range tokens {
+		namespace := t.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		automount := t.AutomountSource
+		if automount == "" {
+			automount = "-"
+		}
+		bindings := strings.Join(t.Bindings, ", ")
+		if bindings == "" {
+			bindings = "-"
+		}
+		if t.ClusterAdmin {
+			bindings += " [CLUSTER ADMIN]"
+		}
+		fmt.Printf("%-20s %-12s %-30s %-20s %-24s %-16s %s\n",
+			namespace, t.Kind, t.Name, t.ServiceAccount, t.Issue, automount, bindings)
+	}
+	
+	fmt.Println()
+	fmt.Println("Note: An automounted token lets anyone who compromises the pod act with its service account's")
+	fmt.Println("RBAC permissions. Set automountServiceAccountToken: false where the API is not needed, and")
+	fmt.Println("replace long-lived token Secrets with short-lived projected tokens.")
+	fmt.Println()
+}
+
+// showAdmissionWebhooksText displays admission webhooks, their caBundle expiry and issues (text output)
+func showAdmissionWebhooksText(webhooks []kubernetes.AdmissionWebhook) {
+	fmt.Println("Admission Webhooks:")
+	fmt.Println("==================")
+	
+	if len(webhooks) == 0 {
+		fmt.Println("No admission webhooks found in the cluster.")
+		fmt.Println()
+		return
+	}
+	
+	flagged := 0
+	for _, w := range webhooks {
+		if len(w.Issues) > 0 {
+			flagged++
+		}
+	}
+	fmt.Printf("Found %d webhooks, %d with issues\n\n", len(webhooks), flagged)
+	
+	fmt.Printf("%-10s %-45s %-8s %-8s %-12s %s\n", "TYPE", "CONFIGURATION/WEBHOOK", "FAILURE", "TIMEOUT", "CA EXPIRES", "ISSUES")
+	fmt.Printf("%-10s %-45s %-8s %-8s %-12s %s\n", "----", "---------------------", "-------", "-------", "----------", "------")
+	for _, w := range webhooks {
+		kind := strings.TrimSuffix(w.Kind, "WebhookConfiguration")
+		expires := "-"
+		for i, c := range w.Certificates {
+			// The earliest expiry decides when the bundle stops working
+			if i == 0 || c.NotAfter.Format("2006-01-02") < expires {
+				expires = c.NotAfter.Format("2006-01-02")
+			}
+		}
+		issues := strings.Join(w.Issues, ", ")
+		if issues == "" {
+			issues = "-"
+		}
+		fmt.Printf("%-10s %-45s %-8s %-8s %-12s %s\n",
+			kind, w.Configuration+"/"+w.Name, w.FailurePolicy, fmt.Sprintf("%ds", w.TimeoutSeconds), expires, issues)
+	}
+	fmt.Println()
+	
+	exemptions := 0
+	for _, w := range webhooks {
+		if slices.Contains(w.Issues, kubernetes.WebhookBroadExemption) {
+			fmt.Printf("%s/%s exempts: %s\n", w.Configuration, w.Name, strings.Join(w.Exempted, ", "))
+			exemptions++
+		}
+	}
+	if exemptions > 0 {
+		fmt.Println()
+	}
+	
+	fmt.Println("Note: Security webhooks that fail open admit every request while they are down, and webhooks")
+	fmt.Println("that fail closed on kube-system can stop control plane components from being recreated.")
+	fmt.Println()
+}
+
+// showSchedulingEscapesText displays workloads that can land on control plane nodes (text output)
+func showSchedulingEscapesText(escapes []kubernetes.SchedulingEscape) {
+	fmt.Println("Scheduling Escape:")
+	fmt.Println("=================")
+	
+	if len(escapes) == 0 {
+		fmt.Println("No workloads able to schedule onto control plane nodes found in the cluster.")
+		fmt.Println()
+		return
+	}
+	
+	fmt.Printf("Found %d workloads able to schedule onto control plane nodes\n\n", len(escapes))
+	fmt.Printf("%-20s %-15s %-30s %-12s %s\n", "NAMESPACE", "RESOURCE TYPE", "NAME", "CONFIRMED", "EVIDENCE")
+	fmt.Printf("%-20s %-15s %-30s %-12s %s\n", "---------", "------------", "----", "---------", "--------")
+	for _, e := range escapes {
+		namespace := e.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		fmt.Printf("%-20s %-15s %-30s %-12s %s\n",
+			namespace, e.Kind, e.Name, e.Confirmation, strings.Join(e.Evidence, ", "))
+		if len(e.ControlPlaneNodes) > 0 {
+			fmt.Printf("  control plane nodes: %s\n", strings.Join(e.ControlPlaneNodes, ", "))
+		}
+		if len(e.HostEscapes) > 0 {
+			fmt.Printf("  can take over the node: %s\n", strings.Join(e.HostEscapes, ", "))
+		}
+	}
+	fmt.Println()
+	
+	fmt.Println("Note: A workload that reaches a control plane node and can also take over its node, through a")
+	fmt.Println("privileged container, hostPID or a writable hostPath, can read etcd data and control plane keys.")
+	fmt.Println("Confirmation uses the control plane Nodes in the snapshot; managed clusters usually have none.")
+	fmt.Println()
+}
+
+// showRiskText displays the cluster's risk score and the namespaces and workloads
+// contributing most to it (text output)
+func showRiskText(report kubernetes.RiskReport) {
+	fmt.Println("Risk Score:")
+	fmt.Println("==========")
+	
+	c := report.Cluster
+	fmt.Printf("Cluster risk score: %d from %d findings (%d critical, %d high, %d medium, %d low)\n",
+		c.Score, c.Findings, c.Critical, c.High, c.Medium, c.Low)
+	h := report.Hygiene
+	fmt.Printf("Hygiene score: %d from %d findings of the images, resources, deprecated-apis, reliability and references checks\n",
+		h.Score, h.Findings)
+	fmt.Println("Each finding adds 10 (critical), 5 (high), 2 (medium) or 1 (low). Pod Security Standards violations that restate another finding, or repeat per container, are scored once. Hygiene findings are not part of the risk score.")
+	fmt.Println()
+	
+	if len(report.Namespaces) > 0 {
+		fmt.Printf("%-30s %-8s %-10s %-10s %s\n", "NAMESPACE", "SCORE", "FINDINGS", "CRITICAL", "HIGH")
+		fmt.Printf("%-30s %-8s %-10s %-10s %s\n", "---------", "-----", "--------", "--------", "----")
+		for i, ns := range report.Namespaces {
+			if i == 10 {
+				fmt.Printf("... and %d more namespaces\n", len(report.Namespaces)-10)
+				break
+			}
+			fmt.Printf("%-30s %-8d %-10d %-10d %d\n", ns.Namespace, ns.Score, ns.Findings, ns.Critical, ns.High)
+		}
+		fmt.Println()
+	}
+	
+	if len(report.Workloads) > 0 {
+		fmt.Println("Highest risk workloads:")
+		fmt.Printf("%-20s %-20s %-30s %-8s %s\n", "NAMESPACE", "KIND", "NAME", "SCORE", "FINDINGS")
+		fmt.Printf("%-20s %-20s %-30s %-8s %s\n", "---------", "----", "----", "-----", "--------")
+		for i, w := range report.Workloads {
+			if i == 10 {
+				break
+			}
+			namespace := w.Namespace
+			if namespace == "" {
+				namespace = "-"
+			}
+			fmt.Printf("%-20s %-20s %-30s %-8d %d\n", namespace, w.Kind, w.Name, w.Score, w.Findings)
+		}
+		fmt.Println()
+	}
+}
+
+// storedFingerprint returns the fingerprint recorded when the latest configuration
+// with a name was ingested, detecting it for configurations stored without one
+func storedFingerprint(store storage.Store, name string, config *kubernetes.ClusterConfig) kubernetes.ClusterFingerprint {
+	if history, err := store.GetConfigHistory(name); err == nil && len(history) > 0 && history[0].Fingerprint != nil {
+		return *history[0].Fingerprint
+	}
+	return kubernetes.FingerprintCluster(config)
+}
+
+// snapshotTime returns when the latest version of a stored configuration was taken,
+// or the zero time, meaning now, when the store cannot tell
+func snapshotTime(store storage.Store, name string) time.Time {
+	if history, err := store.GetConfigHistory(name); err == nil && len(history) > 0 {
+		return history[0].Timestamp
+	}
+	return time.Time{}
+}
+
+// showFingerprint displays the cluster distribution, version and node software
+func showFingerprint(fingerprint kubernetes.ClusterFingerprint) {
+	fmt.Printf("Cluster: %s\n", fingerprint)
+	if len(fingerprint.Evidence) > 0 {
+		fmt.Printf("  Detected from: %s\n", strings.Join(fingerprint.Evidence, ", "))
+	}
+	for _, software := range fingerprint.NodeSoftware() {
+		nodes := "nodes"
+		if software.Count == 1 {
+			nodes = "node"
+		}
+		fmt.Printf("  %d %s: kubelet %s, runtime %s, OS %s, kernel %s\n", software.Count, nodes,
+			valueOrDash(software.KubeletVersion), valueOrDash(software.ContainerRuntime),
+			valueOrDash(software.OSImage), valueOrDash(software.KernelVersion))
+	}
+}
+
+// valueOrDash returns a value, or "-" when it is empty
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// yesNo formats a boolean as Yes or No
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
 func init() {
 	rootCmd.AddCommand(analyzeCmd)
 	analyzeCmd.Flags().StringVarP(&analyzeClusterName, "name", "n", "", "Name of the cluster configuration to analyze (required)")
@@ -432,7 +1280,24 @@ func init() {
 	analyzeCmd.Flags().BoolVar(&capabilityAnalysisFlag, "capabilities", false, "Check for containers with added Linux capabilities")
 	analyzeCmd.Flags().BoolVar(&hostNamespaceAnalysisFlag, "host-namespaces", false, "Check for workloads using host namespaces")
 	analyzeCmd.Flags().BoolVar(&hostPathAnalysisFlag, "host-path", false, "Check for workloads using hostPath volumes")
+	analyzeCmd.Flags().StringVar(&analyzeHostPathCatalogue, "host-path-catalogue", "", "YAML or JSON list of {path, severity, reason} rules overriding or extending the built-in sensitive host path catalogue")
+	analyzeCmd.Flags().BoolVar(&pssAnalysisFlag, "pss", false, "Evaluate workloads against the Pod Security Standards baseline and restricted profiles")
+	analyzeCmd.Flags().BoolVar(&rbacAnalysisFlag, "rbac", false, "Analyze RBAC roles and bindings for dangerous permissions per subject")
+	analyzeCmd.Flags().BoolVar(&networkPolicyAnalysisFlag, "network-policies", false, "Analyze NetworkPolicy coverage of namespaces and workloads")
+	analyzeCmd.Flags().BoolVar(&exposureAnalysisFlag, "exposure", false, "List Services, Ingresses and HTTPRoutes reachable from outside the cluster")
+	analyzeCmd.Flags().BoolVar(&imagesAnalysisFlag, "images", false, "Check container images for latest tags, missing digests, untrusted registries and tag drift")
+	analyzeCmd.Flags().StringSliceVar(&analyzeAllowedRegistries, "allowed-registries", nil, "Registries images may be pulled from, e.g. ghcr.io/my-org,registry.k8s.io (default: any)")
+	analyzeCmd.Flags().BoolVar(&resourcesAnalysisFlag, "resources", false, "Check containers for missing requests and limits and report QoS classes per namespace")
+	analyzeCmd.Flags().BoolVar(&deprecatedAPIsFlag, "deprecated-apis", false, "Check for resources using deprecated or removed API versions")
+	analyzeCmd.Flags().StringVar(&analyzeTargetVersion, "target-version", "", "Kubernetes version to plan an upgrade to for the deprecated API check, e.g. 1.29 (default: report all deprecations)")
+	analyzeCmd.Flags().BoolVar(&reliabilityAnalysisFlag, "reliability", false, "Check workloads for single replicas, blocking PodDisruptionBudgets, missing probes and missing spread")
+	analyzeCmd.Flags().BoolVar(&referencesAnalysisFlag, "references", false, "Check for references to objects missing from the configuration and Services selecting no pods")
+	analyzeCmd.Flags().BoolVar(&secretsAnalysisFlag, "secrets", false, "Scan env values, ConfigMaps and annotations for likely credentials (values are redacted)")
+	analyzeCmd.Flags().BoolVar(&serviceAccountsFlag, "service-accounts", false, "Check for automounted service account tokens with RBAC bindings, legacy token Secrets and default service account use")
+	analyzeCmd.Flags().BoolVar(&webhooksAnalysisFlag, "webhooks", false, "Check admission webhooks for fail-open policies, broad exemptions, kube-system interception, timeouts and caBundle expiry")
+	analyzeCmd.Flags().BoolVar(&schedulingAnalysisFlag, "scheduling", false, "Check for workloads able to schedule onto control plane nodes through tolerations, nodeName, nodeSelectors or node affinity")
 	analyzeCmd.Flags().BoolVar(&htmlOutputFlag, "html", false, "Generate HTML output")
 	analyzeCmd.Flags().StringVarP(&outputFileFlag, "output", "o", "", "File to write output to (default is stdout)")
-	analyzeCmd.MarkFlagRequired("name")
+	analyzeCmd.Flags().StringSliceVar(&analyzeChecks, "check", nil, "Run specific checks by ID (repeatable or comma-separated, see --list-checks)")
+	analyzeCmd.Flags().BoolVar(&listChecksFlag, "list-checks", false, "List the available checks and exit")
 }
\ No newline at end of file
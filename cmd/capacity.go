@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/raesene/eolas/pkg/kubernetes"
+	"github.com/raesene/eolas/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	capacityConfigName     string
+	capacityStorageDir     string
+	capacityUseHomeDir     bool
+	capacityStorageBackend string
+	capacityFormat         string
+	capacityOutputFile     string
+)
+
+// CapacityData is the JSON output of the capacity command
+type CapacityData struct {
+	ConfigName string                    `json:"config_name"`
+	Report     kubernetes.CapacityReport `json:"capacity"`
+}
+
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Report node capacity and overcommit in a stored configuration",
+	Long: `Report CPU and memory allocation for each node and node pool in a stored configuration.
+
+Allocatable capacity is read from Node objects and compared with the requests and
+limits of the pods scheduled to each node. A limit ratio above 100% means the node
+is overcommitted. Workloads whose nodeSelector and tolerations match no node are
+listed as unschedulable.
+
+Output formats: text (default) and json.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if capacityConfigName == "" {
+			fmt.Println("Error: configuration name is required")
+			cmd.Help()
+			return
+		}
+
+		// Validate storage backend
+		if err := storage.ValidateBackend(capacityStorageBackend); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if capacityFormat != "text" && capacityFormat != "json" {
+			fmt.Fprintf(os.Stderr, "Error: format must be 'text' or 'json'\n")
+			os.Exit(1)
+		}
+
+		// Determine storage directory
+		var storeDir string
+		if capacityStorageDir != "" {
+			storeDir = capacityStorageDir
+		} else if capacityUseHomeDir {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error determining home directory: %v\n", err)
+				os.Exit(1)
+			}
+			storeDir = filepath.Join(homeDir, ".eolas")
+		} else {
+			storeDir = ".eolas"
+		}
+
+		store, err := storage.NewStore(storage.StorageConfig{
+			Backend:    storage.Backend(capacityStorageBackend),
+			StorageDir: storeDir,
+			UseHomeDir: capacityUseHomeDir,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error accessing storage: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+
+		config, err := store.LoadConfig(capacityConfigName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration '%s': %v\n", capacityConfigName, err)
+			os.Exit(1)
+		}
+
+		report := kubernetes.GetCapacityReport(config)
+
+		var outputData []byte
+		if capacityFormat == "json" {
+			outputData, err = json.MarshalIndent(CapacityData{
+				ConfigName: capacityConfigName,
+				Report:     report,
+			}, "", "  ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error generating output: %v\n", err)
+				os.Exit(1)
+			}
+			outputData = append(outputData, '\n')
+		} else {
+			outputData = []byte(formatCapacityText(report))
+		}
+
+		if capacityOutputFile == "" || capacityOutputFile == "-" {
+			os.Stdout.Write(outputData)
+			return
+		}
+
+		if err := os.WriteFile(capacityOutputFile, outputData, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Capacity report saved to: %s\n", capacityOutputFile)
+	},
+}
+
+// formatCapacityText renders the capacity report as tables
+func formatCapacityText(report kubernetes.CapacityReport) string {
+	var b strings.Builder
+	b.WriteString("Node Capacity:\n")
+	b.WriteString("=============\n")
+
+	if len(report.Nodes) == 0 {
+		b.WriteString("No Node objects found in the configuration. Ingest a snapshot that includes nodes\n")
+		b.WriteString("(kubectl get nodes,pods -A -o json) to report capacity.\n\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Found %d nodes in %d node pools\n\n", len(report.Nodes), len(report.Pools))
+
+	header := "%-30s %-20s %-5s %-20s %-20s %-22s %s\n"
+	row := "%-30s %-20s %-5d %-20s %-20s %-22s %s\n"
+	fmt.Fprintf(&b, header, "NODE", "POOL", "PODS", "CPU REQ/ALLOC", "CPU LIMITS", "MEMORY REQ/ALLOC", "MEMORY LIMITS")
+	fmt.Fprintf(&b, header, "----", "----", "----", "-------------", "----------", "----------------", "-------------")
+	for _, node := range report.Nodes {
+		name := node.Name
+		if node.Unschedulable {
+			name += " (cordoned)"
+		}
+		fmt.Fprintf(&b, row, name, node.Pool, node.Pods,
+			formatCPUUsage(node.CPURequests, node.CPUAllocatable, node.CPURequestRatio),
+			formatCPUUsage(node.CPULimits, 0, node.CPULimitRatio),
+			formatMemoryUsage(node.MemoryRequests, node.MemoryAllocatable, node.MemoryRequestRatio),
+			formatMemoryUsage(node.MemoryLimits, 0, node.MemoryLimitRatio))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("Node Pools:\n")
+	fmt.Fprintf(&b, header, "POOL", "NODES", "PODS", "CPU REQ/ALLOC", "CPU LIMITS", "MEMORY REQ/ALLOC", "MEMORY LIMITS")
+	fmt.Fprintf(&b, header, "----", "-----", "----", "-------------", "----------", "----------------", "-------------")
+	for _, pool := range report.Pools {
+		fmt.Fprintf(&b, row, pool.Name, fmt.Sprint(pool.Nodes), pool.Pods,
+			formatCPUUsage(pool.CPURequests, pool.CPUAllocatable, pool.CPURequestRatio),
+			formatCPUUsage(pool.CPULimits, 0, pool.CPULimitRatio),
+			formatMemoryUsage(pool.MemoryRequests, pool.MemoryAllocatable, pool.MemoryRequestRatio),
+			formatMemoryUsage(pool.MemoryLimits, 0, pool.MemoryLimitRatio))
+	}
+	total := report.Total
+	fmt.Fprintf(&b, row, "TOTAL", fmt.Sprint(len(report.Nodes)), total.Pods,
+		formatCPUUsage(total.CPURequests, total.CPUAllocatable, total.CPURequestRatio),
+		formatCPUUsage(total.CPULimits, 0, total.CPULimitRatio),
+		formatMemoryUsage(total.MemoryRequests, total.MemoryAllocatable, total.MemoryRequestRatio),
+		formatMemoryUsage(total.MemoryLimits, 0, total.MemoryLimitRatio))
+	b.WriteString("\n")
+
+	if len(report.Unschedulable) > 0 {
+		fmt.Fprintf(&b, "Found %d workloads that cannot schedule on any node\n\n", len(report.Unschedulable))
+		fmt.Fprintf(&b, "%-20s %-15s %-30s %s\n", "NAMESPACE", "RESOURCE TYPE", "NAME", "REASON")
+		fmt.Fprintf(&b, "%-20s %-15s %-30s %s\n", "---------", "-------------", "----", "------")
+		for _, pod := range report.Unschedulable {
+			fmt.Fprintf(&b, "%-20s %-15s %-30s %s\n", pod.Namespace, pod.Kind, pod.Name, pod.Reason)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("Note: Requests above 100% of allocatable cannot occur on a healthy node. Limits above\n")
+	b.WriteString("100% mean the node is overcommitted and pods may be throttled or OOM killed under load.\n\n")
+	return b.String()
+}
+
+// formatCPUUsage formats CPU use against allocatable with its ratio, e.g. "1.5/4 (38%)".
+// With no allocatable only the use and ratio are shown.
+func formatCPUUsage(used, allocatable, ratio float64) string {
+	if allocatable == 0 {
+		return fmt.Sprintf("%s (%s)", kubernetes.FormatCPU(used), kubernetes.FormatRatio(ratio))
+	}
+	return fmt.Sprintf("%s/%s (%s)", kubernetes.FormatCPU(used), kubernetes.FormatCPU(allocatable), kubernetes.FormatRatio(ratio))
+}
+
+// formatMemoryUsage formats memory use against allocatable with its ratio
+func formatMemoryUsage(used, allocatable, ratio float64) string {
+	if allocatable == 0 {
+		return fmt.Sprintf("%s (%s)", kubernetes.FormatMemory(used), kubernetes.FormatRatio(ratio))
+	}
+	return fmt.Sprintf("%s/%s (%s)", kubernetes.FormatMemory(used), kubernetes.FormatMemory(allocatable), kubernetes.FormatRatio(ratio))
+}
+
+func init() {
+	rootCmd.AddCommand(capacityCmd)
+	capacityCmd.Flags().StringVarP(&capacityConfigName, "name", "n", "", "Name of the cluster configuration to analyze (required)")
+	capacityCmd.Flags().StringVarP(&capacityStorageDir, "storage-dir", "s", "", "Directory where configurations are stored (defaults to .eolas in home directory)")
+	capacityCmd.Flags().BoolVarP(&capacityUseHomeDir, "use-home", "", true, "Use .eolas directory in user's home directory")
+	capacityCmd.Flags().StringVar(&capacityStorageBackend, "backend", "file", "Storage backend to use (file, sqlite)")
+	capacityCmd.Flags().StringVarP(&capacityFormat, "format", "f", "text", "Output format (text, json)")
+	capacityCmd.Flags().StringVarP(&capacityOutputFile, "output", "o", "", "Output file (default is stdout)")
+}
@@ -267,7 +267,8 @@ func performFileCleanup(storeDir string, cutoffTime time.Time) error {
 				configName, fileInfo.ModTime().Format("2006-01-02 15:04:05"))
 
 			if !cleanupDryRun {
-				if err := os.Remove(filePath); err != nil {
+				// DeleteConfig also removes the metadata saved next to the file
+				if err := store.DeleteConfig(configName); err != nil {
 					fmt.Printf("  Error deleting %s: %v\n", filePath, err)
 				} else {
 					fmt.Printf("  Deleted %s\n", configName)
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"html"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/raesene/eolas/pkg/kubernetes"
+	"github.com/raesene/eolas/pkg/output"
 	"github.com/raesene/eolas/pkg/storage"
 	"github.com/spf13/cobra"
 )
@@ -81,9 +85,17 @@ For SQLite backend, use 'eolas list --backend sqlite' to see available configura
 			os.Exit(1)
 		}
 
+		// Time to remediate across the history of the newer configuration (SQLite only)
+		lifecycle, err := store.GetFindingLifecycle(comparison.Config2.Name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting finding lifecycle: %v\n", err)
+			os.Exit(1)
+		}
+		remediation := storage.SummarizeRemediation(lifecycle)
+
 		// Handle HTML output if requested
 		if compareHtmlOutput {
-			htmlContent, err := generateComparisonHTML(comparison)
+			htmlContent, err := generateComparisonHTML(comparison, remediation)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating HTML comparison: %v\n", err)
 				os.Exit(1)
@@ -110,12 +122,12 @@ For SQLite backend, use 'eolas list --backend sqlite' to see available configura
 		}
 
 		// Standard text output
-		displayComparisonText(comparison)
+		displayComparisonText(comparison, remediation)
 	},
 }
 
 // displayComparisonText shows the comparison results in text format
-func displayComparisonText(comparison *storage.ConfigComparison) {
+func displayComparisonText(comparison *storage.ConfigComparison, remediation []storage.RemediationStats) {
 	fmt.Printf("Configuration Comparison\n")
 	fmt.Printf("========================\n\n")
 
@@ -162,15 +174,7 @@ func displayComparisonText(comparison *storage.ConfigComparison) {
 	fmt.Printf("%-30s %-10s %-10s %-10s\n", "SECURITY FINDING", "BEFORE", "AFTER", "CHANGE")
 	fmt.Printf("%-30s %-10s %-10s %-10s\n", "----------------", "------", "-----", "------")
 
-	findings := []struct {
-		name string
-		diff storage.SecurityFindingDiff
-	}{
-		{"Privileged Containers", secDiff.PrivilegedContainers},
-		{"Containers w/ Capabilities", secDiff.CapabilityContainers},
-		{"Host Namespace Usage", secDiff.HostNamespaceUsage},
-		{"Host Path Volumes", secDiff.HostPathVolumes},
-	}
+	findings := securityFindingRows(secDiff)
 
 	for _, finding := range findings {
 		changeStr := fmt.Sprintf("%+d", finding.diff.Change)
@@ -180,6 +184,34 @@ func displayComparisonText(comparison *storage.ConfigComparison) {
 		fmt.Printf("%-30s %-10d %-10d %-10s\n", finding.name, finding.diff.Before, finding.diff.After, changeStr)
 	}
 
+	// Individual findings, where both configurations recorded them
+	introduced, resolved, modified := 0, 0, 0
+	for _, finding := range findings {
+		introduced += len(finding.diff.Added)
+		resolved += len(finding.diff.Removed)
+		modified += len(finding.diff.Modified)
+	}
+	showFindingChanges("Introduced Findings", "+", findings, func(diff storage.SecurityFindingDiff) []string { return diff.Added })
+	showFindingChanges("Resolved Findings", "-", findings, func(diff storage.SecurityFindingDiff) []string { return diff.Removed })
+	showFindingChanges("Modified Findings", "~", findings, func(diff storage.SecurityFindingDiff) []string { return diff.Modified })
+
+	if len(remediation) > 0 && remediation[0].Resolved > 0 {
+		heading := fmt.Sprintf("Time to Remediate (%s)", comparison.Config2.Name)
+		fmt.Printf("\n%s:\n", heading)
+		fmt.Printf("%s\n", strings.Repeat("=", len(heading)))
+		fmt.Printf("%-10s %-8s %-10s %-12s %-12s %-12s\n", "SEVERITY", "OPEN", "RESOLVED", "MEAN", "MEDIAN", "LONGEST")
+		fmt.Printf("%-10s %-8s %-10s %-12s %-12s %-12s\n", "--------", "----", "--------", "----", "------", "-------")
+		for _, stats := range remediation {
+			severity := string(stats.Severity)
+			if severity == "" {
+				severity = "all"
+			}
+			fmt.Printf("%-10s %-8d %-10d %-12s %-12s %-12s\n", severity, stats.Open, stats.Resolved,
+				formatRemediationTime(stats.Resolved, stats.Mean), formatRemediationTime(stats.Resolved, stats.Median),
+				formatRemediationTime(stats.Resolved, stats.Longest))
+		}
+	}
+
 	// Summary
 	fmt.Printf("\nSummary:\n")
 	fmt.Printf("========\n")
@@ -192,29 +224,89 @@ func displayComparisonText(comparison *storage.ConfigComparison) {
 	}
 
 	totalSecurityChanges := 0
-	if secDiff.PrivilegedContainers.Change != 0 {
-		totalSecurityChanges++
-	}
-	if secDiff.CapabilityContainers.Change != 0 {
-		totalSecurityChanges++
-	}
-	if secDiff.HostNamespaceUsage.Change != 0 {
-		totalSecurityChanges++
-	}
-	if secDiff.HostPathVolumes.Change != 0 {
-		totalSecurityChanges++
+	for _, finding := range findings {
+		if finding.diff.Change != 0 {
+			totalSecurityChanges++
+		}
 	}
 
 	fmt.Printf("- %d resource types changed\n", totalResourceChanges)
 	fmt.Printf("- %d security finding types changed\n", totalSecurityChanges)
+	if introduced+resolved+modified > 0 {
+		fmt.Printf("- %d findings introduced, %d resolved, %d modified\n", introduced, resolved, modified)
+	}
 
 	if totalResourceChanges == 0 && totalSecurityChanges == 0 {
 		fmt.Printf("- No significant differences detected\n")
 	}
 }
 
+// securityFindingRow is one line of the security differences table
+type securityFindingRow struct {
+	name string
+	diff storage.SecurityFindingDiff
+}
+
+// securityFindingRows lists the security differences for every registered analyzer,
+// followed by any analyzers present in the stored results that are no longer registered
+func securityFindingRows(secDiff storage.SecurityDifference) []securityFindingRow {
+	var rows []securityFindingRow
+	seen := make(map[string]bool)
+	
+	for _, analyzer := range kubernetes.Analyzers() {
+		seen[analyzer.ID()] = true
+		rows = append(rows, securityFindingRow{analyzer.Name(), secDiff.Findings[analyzer.ID()]})
+	}
+	
+	var unknown []string
+	for id := range secDiff.Findings {
+		if !seen[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+	for _, id := range unknown {
+		rows = append(rows, securityFindingRow{id, secDiff.Findings[id]})
+	}
+	
+	return rows
+}
+
+// showFindingChanges lists one kind of finding change for every analyzer that has any
+func showFindingChanges(title, marker string, rows []securityFindingRow, changes func(diff storage.SecurityFindingDiff) []string) {
+	total := 0
+	for _, row := range rows {
+		total += len(changes(row.diff))
+	}
+	if total == 0 {
+		return
+	}
+
+	heading := fmt.Sprintf("%s (%d)", title, total)
+	fmt.Printf("\n%s:\n", heading)
+	fmt.Printf("%s\n", strings.Repeat("=", len(heading)))
+	for _, row := range rows {
+		list := changes(row.diff)
+		if len(list) == 0 {
+			continue
+		}
+		fmt.Printf("%s:\n", row.name)
+		for _, change := range list {
+			fmt.Printf("  %s %s\n", marker, change)
+		}
+	}
+}
+
+// formatRemediationTime formats a time to resolve, or "-" when no finding was resolved
+func formatRemediationTime(resolved int, d time.Duration) string {
+	if resolved == 0 {
+		return "-"
+	}
+	return output.FormatDuration(d)
+}
+
 // generateComparisonHTML creates HTML output for comparison results
-func generateComparisonHTML(comparison *storage.ConfigComparison) ([]byte, error) {
+func generateComparisonHTML(comparison *storage.ConfigComparison, remediation []storage.RemediationStats) ([]byte, error) {
 	htmlContent := `<!DOCTYPE html>
 <html lang="en">
 <head>
@@ -324,15 +416,7 @@ func generateComparisonHTML(comparison *storage.ConfigComparison) ([]byte, error
             <tbody>`
 
 	secDiff := comparison.SecurityDiff
-	findings := []struct {
-		name string
-		diff storage.SecurityFindingDiff
-	}{
-		{"Privileged Containers", secDiff.PrivilegedContainers},
-		{"Containers w/ Capabilities", secDiff.CapabilityContainers},
-		{"Host Namespace Usage", secDiff.HostNamespaceUsage},
-		{"Host Path Volumes", secDiff.HostPathVolumes},
-	}
+	findings := securityFindingRows(secDiff)
 
 	for _, finding := range findings {
 		changeClass := "neutral"
@@ -358,6 +442,85 @@ func generateComparisonHTML(comparison *storage.ConfigComparison) ([]byte, error
             </tbody>
         </table>`
 
+	// Individual findings, where both configurations recorded them
+	introduced, resolved, modified := 0, 0, 0
+	var changeRows string
+	for _, finding := range findings {
+		for _, change := range []struct {
+			label string
+			class string
+			list  []string
+		}{
+			{"Introduced", "negative", finding.diff.Added},
+			{"Resolved", "positive", finding.diff.Removed},
+			{"Modified", "neutral", finding.diff.Modified},
+		} {
+			for _, item := range change.list {
+				changeRows += fmt.Sprintf(`
+            <tr>
+                <td class="%s">%s</td>
+                <td>%s</td>
+                <td>%s</td>
+            </tr>`, change.class, change.label, html.EscapeString(finding.name), html.EscapeString(item))
+			}
+		}
+		introduced += len(finding.diff.Added)
+		resolved += len(finding.diff.Removed)
+		modified += len(finding.diff.Modified)
+	}
+	if changeRows != "" {
+		htmlContent += `
+        <h2>Finding Changes</h2>
+        <table>
+            <thead>
+                <tr>
+                    <th>Change</th>
+                    <th>Check</th>
+                    <th>Finding</th>
+                </tr>
+            </thead>
+            <tbody>` + changeRows + `
+            </tbody>
+        </table>`
+	}
+
+	if len(remediation) > 0 && remediation[0].Resolved > 0 {
+		htmlContent += `
+        <h2>Time to Remediate (` + html.EscapeString(comparison.Config2.Name) + `)</h2>
+        <table>
+            <thead>
+                <tr>
+                    <th>Severity</th>
+                    <th>Open</th>
+                    <th>Resolved</th>
+                    <th>Mean</th>
+                    <th>Median</th>
+                    <th>Longest</th>
+                </tr>
+            </thead>
+            <tbody>`
+		for _, stats := range remediation {
+			severity := string(stats.Severity)
+			if severity == "" {
+				severity = "all"
+			}
+			htmlContent += fmt.Sprintf(`
+            <tr>
+                <td>%s</td>
+                <td>%d</td>
+                <td>%d</td>
+                <td>%s</td>
+                <td>%s</td>
+                <td>%s</td>
+            </tr>`, severity, stats.Open, stats.Resolved,
+				formatRemediationTime(stats.Resolved, stats.Mean), formatRemediationTime(stats.Resolved, stats.Median),
+				formatRemediationTime(stats.Resolved, stats.Longest))
+		}
+		htmlContent += `
+            </tbody>
+        </table>`
+	}
+
 	// Summary section
 	totalResourceChanges := 0
 	for _, diff := range comparison.ResourceDiff {
@@ -367,17 +530,10 @@ func generateComparisonHTML(comparison *storage.ConfigComparison) ([]byte, error
 	}
 
 	totalSecurityChanges := 0
-	if secDiff.PrivilegedContainers.Change != 0 {
-		totalSecurityChanges++
-	}
-	if secDiff.CapabilityContainers.Change != 0 {
-		totalSecurityChanges++
-	}
-	if secDiff.HostNamespaceUsage.Change != 0 {
-		totalSecurityChanges++
-	}
-	if secDiff.HostPathVolumes.Change != 0 {
-		totalSecurityChanges++
+	for _, finding := range findings {
+		if finding.diff.Change != 0 {
+			totalSecurityChanges++
+		}
 	}
 
 	htmlContent += fmt.Sprintf(`
@@ -387,6 +543,11 @@ func generateComparisonHTML(comparison *storage.ConfigComparison) ([]byte, error
                 <li><strong>%d</strong> resource types changed</li>
                 <li><strong>%d</strong> security finding types changed</li>`, totalResourceChanges, totalSecurityChanges)
 
+	if introduced+resolved+modified > 0 {
+		htmlContent += fmt.Sprintf(`<li><strong>%d</strong> findings introduced, <strong>%d</strong> resolved, <strong>%d</strong> modified</li>`,
+			introduced, resolved, modified)
+	}
+
 	if totalResourceChanges == 0 && totalSecurityChanges == 0 {
 		htmlContent += `<li class="no-changes">No significant differences detected</li>`
 	}
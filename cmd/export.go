@@ -21,6 +21,9 @@ var (
 	exportStorageBackend string
 	exportFormat        string
 	exportOutputFile    string
+	exportAllowedRegistries []string
+	exportTargetVersion string
+	exportHostPathCatalogue string
 	exportType          string
 )
 
@@ -36,16 +39,28 @@ type ExportData struct {
 	CapabilityContainers []kubernetes.CapabilityContainer    `json:"capability_containers"`
 	HostNamespaceWorkloads []kubernetes.HostNamespaceWorkload `json:"host_namespace_workloads"`
 	HostPathVolumes      []kubernetes.HostPathVolume         `json:"host_path_volumes"`
+	ServiceAccountTokens []kubernetes.ServiceAccountToken     `json:"service_account_tokens"`
+	RBACPermissions      []kubernetes.SubjectPermissions      `json:"rbac_permissions"`
+	NetworkPolicies      *kubernetes.NetworkPolicyResult      `json:"network_policies,omitempty"`
+	ExposedServices      []kubernetes.ExposureEntry           `json:"exposed_services"`
+	Reliability          *kubernetes.ReliabilityResult        `json:"reliability,omitempty"`
+	Findings             map[string][]kubernetes.Finding      `json:"findings"` // keyed by analyzer ID
+	Risk                 *kubernetes.RiskReport               `json:"risk,omitempty"`
 	SecuritySummary      SecuritySummary                      `json:"security_summary"`
 }
 
 // SecuritySummary provides a summary of security findings
 type SecuritySummary struct {
-	TotalFindings        int `json:"total_findings"`
+	TotalFindings        int `json:"total_findings"` // findings of the security analyzers
+	HygieneFindings      int `json:"hygiene_findings"` // findings of the hygiene analyzers
 	PrivilegedCount      int `json:"privileged_count"`
 	CapabilityCount      int `json:"capability_count"`
 	HostNamespaceCount   int `json:"host_namespace_count"`
 	HostPathCount        int `json:"host_path_count"`
+	ServiceAccountTokenCount int `json:"service_account_token_count"`
+	FindingCounts        map[string]int `json:"finding_counts"` // keyed by analyzer ID
+	Risk                 kubernetes.RiskScore `json:"risk"` // weighted cluster score and findings per severity
+	Hygiene              kubernetes.RiskScore `json:"hygiene"` // weighted score of the hygiene analyzers' findings
 }
 
 var exportCmd = &cobra.Command{
@@ -67,6 +82,9 @@ Examples:
   # Export only security findings as CSV
   eolas export --name prod-cluster --format csv --type security
 
+  # Export reliability issues as CSV
+  eolas export --name prod-cluster --format csv --type reliability
+
   # Export to specific file
   eolas export --name prod-cluster --format json -o analysis.json`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -84,8 +102,8 @@ Examples:
 		}
 
 		// Validate type
-		if exportType != "all" && exportType != "security" && exportType != "resources" {
-			fmt.Fprintf(os.Stderr, "Error: Invalid type '%s'. Valid types are: all, security, resources\n", exportType)
+		if exportType != "all" && exportType != "security" && exportType != "resources" && exportType != "reliability" {
+			fmt.Fprintf(os.Stderr, "Error: Invalid type '%s'. Valid types are: all, security, resources, reliability\n", exportType)
 			os.Exit(1)
 		}
 
@@ -94,6 +112,11 @@ Examples:
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		opts, err := analyzerOptions(exportAllowedRegistries, exportTargetVersion, exportHostPathCatalogue)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 
 		// Determine storage directory
 		var storeDir string
@@ -156,7 +179,8 @@ Examples:
 			}
 		}
 
-		// Perform analysis
+		// Perform analysis, measuring time-based checks against the snapshot's time
+		opts.Now = metadata.Timestamp
 		resourceCounts := kubernetes.GetResourceCounts(config)
 		totalResources := 0
 		for _, count := range resourceCounts {
@@ -167,12 +191,42 @@ Examples:
 		var capabilityContainers []kubernetes.CapabilityContainer
 		var hostNamespaceWorkloads []kubernetes.HostNamespaceWorkload
 		var hostPathVolumes []kubernetes.HostPathVolume
-
+		var serviceAccountTokens []kubernetes.ServiceAccountToken
+		var rbacPermissions []kubernetes.SubjectPermissions
+		var networkPolicies *kubernetes.NetworkPolicyResult
+		var exposedServices []kubernetes.ExposureEntry
+		var reliability *kubernetes.ReliabilityResult
+		var findings map[string][]kubernetes.Finding
+		var risk *kubernetes.RiskReport
 		if exportType == "all" || exportType == "security" {
 			privilegedContainers = kubernetes.GetPrivilegedContainers(config)
 			capabilityContainers = kubernetes.GetCapabilityContainers(config)
 			hostNamespaceWorkloads = kubernetes.GetHostNamespaceWorkloads(config)
-			hostPathVolumes = kubernetes.GetHostPathVolumes(config)
+			hostPathVolumes = kubernetes.GetHostPathVolumes(config, opts)
+			serviceAccountTokens = kubernetes.GetServiceAccountTokens(config)
+			rbacPermissions = kubernetes.GetRBACPermissions(config)
+			netpol := kubernetes.AnalyzeNetworkPolicies(config)
+			networkPolicies = &netpol
+			exposedServices = kubernetes.GetExposedServices(config, opts)
+			findings = kubernetes.RunAnalyzers(config, opts)
+			report := kubernetes.ScoreFindings(findings)
+			risk = &report
+		}
+		if exportType == "all" || exportType == "reliability" {
+			result := kubernetes.AnalyzeReliability(config)
+			reliability = &result
+		}
+
+		totalFindings := 0
+		hygieneFindings := 0
+		findingCounts := make(map[string]int)
+		for id, results := range findings {
+			findingCounts[id] = len(results)
+			if kubernetes.IsHygieneAnalyzer(id) {
+				hygieneFindings += len(results)
+			} else {
+				totalFindings += len(results)
+			}
 		}
 
 		// Create export data structure
@@ -187,14 +241,28 @@ Examples:
 			CapabilityContainers:   capabilityContainers,
 			HostNamespaceWorkloads: hostNamespaceWorkloads,
 			HostPathVolumes:        hostPathVolumes,
+			ServiceAccountTokens:   serviceAccountTokens,
+			RBACPermissions:        rbacPermissions,
+			NetworkPolicies:        networkPolicies,
+			ExposedServices:        exposedServices,
+			Reliability:            reliability,
+			Findings:               findings,
+			Risk:                   risk,
 			SecuritySummary: SecuritySummary{
-				TotalFindings:      len(privilegedContainers) + len(capabilityContainers) + len(hostNamespaceWorkloads) + len(hostPathVolumes),
+				TotalFindings:      totalFindings,
+				HygieneFindings:    hygieneFindings,
 				PrivilegedCount:    len(privilegedContainers),
 				CapabilityCount:    len(capabilityContainers),
 				HostNamespaceCount: len(hostNamespaceWorkloads),
 				HostPathCount:      len(hostPathVolumes),
+				ServiceAccountTokenCount: len(serviceAccountTokens),
+				FindingCounts:      findingCounts,
 			},
 		}
+		if risk != nil {
+			exportData.SecuritySummary.Risk = risk.Cluster
+			exportData.SecuritySummary.Hygiene = risk.Hygiene
+		}
 
 		// Export based on format
 		var outputData []byte
@@ -220,8 +288,8 @@ Examples:
 			outputFile = fmt.Sprintf("%s-%s-export.%s", exportConfigName, exportType, defaultExt)
 		}
 
-		// Automatically add extension if not present
-		if !strings.HasSuffix(strings.ToLower(outputFile), "."+defaultExt) {
+		// Automatically add extension if not present, leaving "-" (stdout) alone
+		if outputFile != "-" && !strings.HasSuffix(strings.ToLower(outputFile), "."+defaultExt) {
 			outputFile += "." + defaultExt
 		}
 
@@ -242,6 +310,11 @@ Examples:
 			fmt.Printf("Output file: %s\n", outputFile)
 			fmt.Printf("Total resources: %d\n", totalResources)
 			fmt.Printf("Security findings: %d\n", exportData.SecuritySummary.TotalFindings)
+			fmt.Printf("Hygiene findings: %d\n", exportData.SecuritySummary.HygieneFindings)
+			if risk != nil {
+				fmt.Printf("Risk score: %d\n", risk.Cluster.Score)
+				fmt.Printf("Hygiene score: %d\n", risk.Hygiene.Score)
+			}
 		}
 	},
 }
@@ -261,8 +334,24 @@ func exportAsJSON(data ExportData, exportType string) ([]byte, error) {
 			"capability_containers":   data.CapabilityContainers,
 			"host_namespace_workloads": data.HostNamespaceWorkloads,
 			"host_path_volumes":       data.HostPathVolumes,
+			"service_account_tokens":  data.ServiceAccountTokens,
+			"rbac_permissions":        data.RBACPermissions,
+			"network_policies":        data.NetworkPolicies,
+			"exposed_services":        data.ExposedServices,
+			"findings":                data.Findings,
+			"risk":                    data.Risk,
 		}
 		return json.MarshalIndent(securityData, "", "  ")
+	case "reliability":
+		// Export only reliability data
+		reliabilityData := map[string]interface{}{
+			"config_name": data.ConfigName,
+			"config_id":   data.ConfigID,
+			"timestamp":   data.Timestamp,
+			"exported_at": data.ExportedAt,
+			"reliability": data.Reliability,
+		}
+		return json.MarshalIndent(reliabilityData, "", "  ")
 	case "resources":
 		// Export only resource data
 		resourceData := map[string]interface{}{
@@ -289,42 +378,48 @@ func exportAsCSV(data ExportData, exportType string) ([]byte, error) {
 		// CSV header for security findings
 		records = append(records, []string{
 			"Finding Type", "Namespace", "Resource Type", "Resource Name", 
-			"Container Name", "Details", "Timestamp",
+			"Container Name", "Details", "Timestamp", "Severity",
 		})
 
-		// Add privileged containers
-		for _, pc := range data.PrivilegedContainers {
-			records = append(records, []string{
-				"Privileged Container", pc.Namespace, pc.Kind, pc.PodName,
-				pc.Name, "Privileged: true", data.Timestamp.Format(time.RFC3339),
-			})
+		// Add the findings of every registered analyzer
+		for _, analyzer := range kubernetes.Analyzers() {
+			for _, f := range data.Findings[analyzer.ID()] {
+				container := f.Container
+				if container == "" {
+					container = "-"
+				}
+				records = append(records, []string{
+					analyzer.Name(), f.Namespace, f.Kind, f.Name,
+					container, f.Details, data.Timestamp.Format(time.RFC3339), string(f.Severity),
+				})
+			}
 		}
 
-		// Add capability containers
-		for _, cc := range data.CapabilityContainers {
-			caps := strings.Join(cc.Capabilities, ", ")
-			records = append(records, []string{
-				"Container Capabilities", cc.Namespace, cc.Kind, cc.PodName,
-				cc.Name, "Capabilities: " + caps, data.Timestamp.Format(time.RFC3339),
-			})
-		}
+	case "reliability":
+		// CSV header for reliability issues, one row per workload issue or blocking PDB
+		records = append(records, []string{
+			"Namespace", "Resource Type", "Resource Name", "Replicas", "Issue", "Details", "Timestamp",
+		})
 
-		// Add host namespace workloads
-		for _, hn := range data.HostNamespaceWorkloads {
-			details := fmt.Sprintf("HostPID: %t, HostIPC: %t, HostNetwork: %t", 
-				hn.HostPID, hn.HostIPC, hn.HostNetwork)
-			records = append(records, []string{
-				"Host Namespace", hn.Namespace, hn.Kind, hn.Name,
-				strings.Join(hn.ContainerNames, ", "), details, data.Timestamp.Format(time.RFC3339),
-			})
+		for _, w := range data.Reliability.Workloads {
+			for _, issue := range w.Issues {
+				details := ""
+				switch issue {
+				case kubernetes.ReliabilityNoReadiness:
+					details = strings.Join(w.NoReadinessProbe, ";")
+				case kubernetes.ReliabilityNoLiveness:
+					details = strings.Join(w.NoLivenessProbe, ";")
+				}
+				records = append(records, []string{
+					w.Namespace, w.Kind, w.Name, fmt.Sprintf("%d", w.Replicas), issue, details,
+					data.Timestamp.Format(time.RFC3339),
+				})
+			}
 		}
-
-		// Add host path volumes
-		for _, hp := range data.HostPathVolumes {
-			paths := strings.Join(hp.HostPaths, ", ")
+		for _, p := range data.Reliability.BlockingPDBs {
 			records = append(records, []string{
-				"Host Path Volume", hp.Namespace, hp.Kind, hp.Name,
-				"-", "Paths: " + paths, data.Timestamp.Format(time.RFC3339),
+				p.Namespace, "PodDisruptionBudget", p.Name, "", kubernetes.ReliabilityBlockingPDB, p.Reason,
+				data.Timestamp.Format(time.RFC3339),
 			})
 		}
 
@@ -356,13 +451,15 @@ func exportAsCSV(data ExportData, exportType string) ([]byte, error) {
 			data.ConfigName, data.Timestamp.Format(time.RFC3339),
 		})
 		records = append(records, []string{
-			"Privileged Containers", fmt.Sprintf("%d", data.SecuritySummary.PrivilegedCount), 
-			data.ConfigName, data.Timestamp.Format(time.RFC3339),
-		})
-		records = append(records, []string{
-			"Capability Containers", fmt.Sprintf("%d", data.SecuritySummary.CapabilityCount), 
+			"Total Hygiene Findings", fmt.Sprintf("%d", data.SecuritySummary.HygieneFindings), 
 			data.ConfigName, data.Timestamp.Format(time.RFC3339),
 		})
+		for _, analyzer := range kubernetes.Analyzers() {
+			records = append(records, []string{
+				analyzer.Name(), fmt.Sprintf("%d", data.SecuritySummary.FindingCounts[analyzer.ID()]), 
+				data.ConfigName, data.Timestamp.Format(time.RFC3339),
+			})
+		}
 	}
 
 	// Convert records to CSV
@@ -391,6 +488,9 @@ func init() {
 	exportCmd.Flags().StringVar(&exportStorageBackend, "backend", "file", "Storage backend to use (file, sqlite)")
 	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "json", "Export format (json, csv)")
 	exportCmd.Flags().StringVarP(&exportOutputFile, "output", "o", "", "Output file (default: <config>-<type>-export.<format>, use '-' for stdout)")
-	exportCmd.Flags().StringVarP(&exportType, "type", "t", "all", "Export type (all, security, resources)")
+	exportCmd.Flags().StringVarP(&exportType, "type", "t", "all", "Export type (all, security, resources, reliability)")
+	exportCmd.Flags().StringSliceVar(&exportAllowedRegistries, "allowed-registries", nil, "Registries images may be pulled from, used by the image check (default: any)")
+	exportCmd.Flags().StringVar(&exportTargetVersion, "target-version", "", "Kubernetes version to plan an upgrade to, used by the deprecated API check (default: report all deprecations)")
+	exportCmd.Flags().StringVar(&exportHostPathCatalogue, "host-path-catalogue", "", "YAML or JSON list of {path, severity, reason} rules overriding or extending the built-in sensitive host path catalogue")
 	exportCmd.MarkFlagRequired("name")
 }
\ No newline at end of file
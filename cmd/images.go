@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/raesene/eolas/pkg/kubernetes"
+	"github.com/raesene/eolas/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	imagesConfigName     string
+	imagesStorageDir     string
+	imagesUseHomeDir     bool
+	imagesStorageBackend string
+	imagesFormat         string
+	imagesOutputFile     string
+	imagesAllowed        []string
+)
+
+// ImagesData is the JSON output of the images command
+type ImagesData struct {
+	ConfigName        string                  `json:"config_name"`
+	AllowedRegistries []string                `json:"allowed_registries,omitempty"`
+	Images            []kubernetes.ImageInfo  `json:"images"`
+	Drift             []kubernetes.ImageDrift `json:"drift"`
+}
+
+var imagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "List the container images in a stored configuration",
+	Long: `List every container image used by the workloads in a stored configuration.
+
+Images are deduplicated across owners and checked for:
+- the latest tag or no tag
+- not being pinned by digest
+- registries outside the allowlist (--allowed-registries)
+- the same repository running at different tags across namespaces
+
+Output formats: text (default), json and csv.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if imagesConfigName == "" {
+			fmt.Println("Error: configuration name is required")
+			cmd.Help()
+			return
+		}
+
+		// Validate storage backend
+		if err := storage.ValidateBackend(imagesStorageBackend); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if imagesFormat != "text" && imagesFormat != "json" && imagesFormat != "csv" {
+			fmt.Fprintf(os.Stderr, "Error: format must be 'text', 'json' or 'csv'\n")
+			os.Exit(1)
+		}
+
+		// Determine storage directory
+		var storeDir string
+		if imagesStorageDir != "" {
+			storeDir = imagesStorageDir
+		} else if imagesUseHomeDir {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error determining home directory: %v\n", err)
+				os.Exit(1)
+			}
+			storeDir = filepath.Join(homeDir, ".eolas")
+		} else {
+			storeDir = ".eolas"
+		}
+
+		store, err := storage.NewStore(storage.StorageConfig{
+			Backend:    storage.Backend(imagesStorageBackend),
+			StorageDir: storeDir,
+			UseHomeDir: imagesUseHomeDir,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error accessing storage: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+
+		config, err := store.LoadConfig(imagesConfigName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration '%s': %v\n", imagesConfigName, err)
+			os.Exit(1)
+		}
+
+		inventory := kubernetes.GetImageInventory(config, kubernetes.Options{AllowedRegistries: imagesAllowed})
+
+		var outputData []byte
+		switch imagesFormat {
+		case "json":
+			outputData, err = json.MarshalIndent(ImagesData{
+				ConfigName:        imagesConfigName,
+				AllowedRegistries: imagesAllowed,
+				Images:            inventory.Images,
+				Drift:             inventory.Drift,
+			}, "", "  ")
+			outputData = append(outputData, '\n')
+		case "csv":
+			outputData, err = formatImagesCSV(inventory)
+		default:
+			outputData = []byte(formatImagesText(inventory))
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating output: %v\n", err)
+			os.Exit(1)
+		}
+
+		if imagesOutputFile == "" || imagesOutputFile == "-" {
+			os.Stdout.Write(outputData)
+			return
+		}
+
+		if err := os.WriteFile(imagesOutputFile, outputData, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Image inventory saved to: %s\n", imagesOutputFile)
+	},
+}
+
+// formatImagesText renders the image inventory as tables
+func formatImagesText(inventory kubernetes.ImageInventory) string {
+	var b strings.Builder
+	b.WriteString("Container Images:\n")
+	b.WriteString("================\n")
+
+	if len(inventory.Images) == 0 {
+		b.WriteString("No container images found in the cluster.\n\n")
+		return b.String()
+	}
+
+	withIssues := 0
+	for _, info := range inventory.Images {
+		if len(info.Issues) > 0 {
+			withIssues++
+		}
+	}
+	fmt.Fprintf(&b, "Found %d distinct images, %d with issues\n\n", len(inventory.Images), withIssues)
+
+	fmt.Fprintf(&b, "%-55s %-20s %-6s %-25s %s\n", "IMAGE", "REGISTRY", "USES", "NAMESPACES", "ISSUES")
+	fmt.Fprintf(&b, "%-55s %-20s %-6s %-25s %s\n", "-----", "--------", "----", "----------", "------")
+	for _, info := range inventory.Images {
+		var namespaces []string
+		for _, use := range info.Uses {
+			namespace := use.Namespace
+			if namespace == "" {
+				namespace = "default"
+			}
+			if !slices.Contains(namespaces, namespace) {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+		issues := strings.Join(info.Issues, ", ")
+		if issues == "" {
+			issues = "-"
+		}
+		fmt.Fprintf(&b, "%-55s %-20s %-6d %-25s %s\n", info.Image, info.Registry, len(info.Uses), strings.Join(namespaces, ","), issues)
+	}
+	b.WriteString("\n")
+
+	if len(inventory.Drift) > 0 {
+		fmt.Fprintf(&b, "Found %d repositories running at different tags across namespaces\n\n", len(inventory.Drift))
+		fmt.Fprintf(&b, "%-50s %s\n", "REPOSITORY", "VERSIONS BY NAMESPACE")
+		fmt.Fprintf(&b, "%-50s %s\n", "----------", "---------------------")
+		for _, drift := range inventory.Drift {
+			var byNamespace []string
+			for _, namespace := range drift.Namespaces {
+				byNamespace = append(byNamespace, namespace+": "+strings.Join(drift.NamespaceVersions[namespace], ", "))
+			}
+			fmt.Fprintf(&b, "%-50s %s\n", drift.Repository, strings.Join(byNamespace, "; "))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("Note: Mutable tags such as latest can change the code that runs without any change to the\n")
+	b.WriteString("workload. Pin images by digest and pull only from trusted registries (--allowed-registries).\n\n")
+	return b.String()
+}
+
+// formatImagesCSV renders the image inventory as CSV, one row per container
+func formatImagesCSV(inventory kubernetes.ImageInventory) ([]byte, error) {
+	var csvData strings.Builder
+	writer := csv.NewWriter(&csvData)
+
+	writer.Write([]string{
+		"Image", "Registry", "Repository", "Tag", "Digest",
+		"Namespace", "Resource Type", "Resource Name", "Container Name", "Issues",
+	})
+	for _, info := range inventory.Images {
+		for _, use := range info.Uses {
+			writer.Write([]string{
+				info.Image, info.Registry, info.Repository, info.Tag, info.Digest,
+				use.Namespace, use.Kind, use.PodName, use.Name, strings.Join(info.Issues, ";"),
+			})
+		}
+	}
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("error writing CSV: %w", err)
+	}
+	return []byte(csvData.String()), nil
+}
+
+func init() {
+	rootCmd.AddCommand(imagesCmd)
+	imagesCmd.Flags().StringVarP(&imagesConfigName, "name", "n", "", "Name of the cluster configuration to analyze (required)")
+	imagesCmd.Flags().StringVarP(&imagesStorageDir, "storage-dir", "s", "", "Directory where configurations are stored (defaults to .eolas in home directory)")
+	imagesCmd.Flags().BoolVarP(&imagesUseHomeDir, "use-home", "", true, "Use .eolas directory in user's home directory")
+	imagesCmd.Flags().StringVar(&imagesStorageBackend, "backend", "file", "Storage backend to use (file, sqlite)")
+	imagesCmd.Flags().StringVarP(&imagesFormat, "format", "f", "text", "Output format (text, json, csv)")
+	imagesCmd.Flags().StringVarP(&imagesOutputFile, "output", "o", "", "Output file (default is stdout)")
+	imagesCmd.Flags().StringSliceVar(&imagesAllowed, "allowed-registries", nil, "Registries images may be pulled from, e.g. ghcr.io/my-org,registry.k8s.io (default: any)")
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,19 +12,35 @@ import (
 
 var (
 	inputFile      string
+	inputDir       string
+	inputArchive   string
 	clusterName    string
 	storageDir     string
 	useHomeDir     bool
 	storageBackend string
+
+	ingestAllowedRegistries []string
+	ingestTargetVersion     string
+	ingestHostPathCatalogue string
 )
 
 var ingestCmd = &cobra.Command{
 	Use:   "ingest",
-	Short: "Ingest a Kubernetes cluster configuration JSON file",
-	Long:  `Ingest a JSON file containing Kubernetes cluster configuration for analysis.`,
+	Short: "Ingest a Kubernetes cluster configuration from JSON/YAML files, directories or archives",
+	Long: `Ingest a file containing Kubernetes cluster configuration for analysis.
+
+Supported input formats:
+- JSON List output from 'kubectl get -o json'
+- YAML output from 'kubectl get -o yaml'
+- Multi-document YAML manifest bundles separated by '---'
+- A single JSON or YAML object
+
+Use --dir or --archive to merge every .json, .yaml and .yml file in a directory
+tree or a tar, tar.gz or zip archive (for example support bundles or must-gather
+output) into one configuration. Each resource records the file it was read from.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if inputFile == "" {
-			fmt.Println("Error: input file is required")
+		if inputFile == "" && inputDir == "" && inputArchive == "" {
+			fmt.Println("Error: one of --file, --dir or --archive is required")
 			cmd.Help()
 			return
 		}
@@ -35,44 +50,12 @@ var ingestCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-
-		// Check if file exists
-		absPath, err := filepath.Abs(inputFile)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error resolving file path: %v\n", err)
-			os.Exit(1)
-		}
-
-		fmt.Printf("Ingesting file: %s\n", absPath)
-		_, err = os.Stat(absPath)
-		if os.IsNotExist(err) {
-			fmt.Fprintf(os.Stderr, "Error: file %s does not exist\n", absPath)
-			os.Exit(1)
-		}
-
-		// Read and parse JSON
-		data, err := readJSON(absPath)
+		opts, err := analyzerOptions(ingestAllowedRegistries, ingestTargetVersion, ingestHostPathCatalogue)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading JSON file: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Parse Kubernetes configuration
-		config, err := kubernetes.ParseConfig(data)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error parsing Kubernetes configuration: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		// Display resource counts
-		resourceCounts := kubernetes.GetResourceCounts(config)
-		fmt.Println("Successfully ingested Kubernetes configuration")
-		fmt.Printf("File size: %d bytes\n", len(data))
-		fmt.Println("Resource counts:")
-		for kind, count := range resourceCounts {
-			fmt.Printf("  %s: %d\n", kind, count)
-		}
-
 		// Determine storage directory
 		var storeDir string
 		if storageDir != "" {
@@ -91,11 +74,12 @@ var ingestCmd = &cobra.Command{
 			storeDir = ".eolas"
 		}
 
-		// Create storage backend
+		// Create storage backend; the SQLite backend stores an analysis run with these options
 		storageConfig := storage.StorageConfig{
-			Backend:    storage.Backend(storageBackend),
-			StorageDir: storeDir,
-			UseHomeDir: useHomeDir,
+			Backend:         storage.Backend(storageBackend),
+			StorageDir:      storeDir,
+			UseHomeDir:      useHomeDir,
+			AnalyzerOptions: opts,
 		}
 
 		store, err := storage.NewStore(storageConfig)
@@ -105,7 +89,48 @@ var ingestCmd = &cobra.Command{
 		}
 		defer store.Close()
 
-		if err := store.SaveConfig(config, clusterName); err != nil {
+		writer, err := store.BeginConfig(storage.ConfigMetadata{Name: clusterName})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Each item is written to storage as soon as it is decoded. The analyzers
+		// correlate resources across the whole cluster, so a compact copy of every
+		// item, without the fields no analyzer reads, is kept for them.
+		config := &kubernetes.ClusterConfig{ApiVersion: "v1", Kind: "List"}
+		add := func(item kubernetes.Item) error {
+			if err := writer.Add(item); err != nil {
+				return err
+			}
+			config.Items = append(config.Items, item.Compact())
+			return nil
+		}
+
+		// Load the configuration from the selected input
+		switch {
+		case inputDir != "":
+			err = ingestDirectory(inputDir, add)
+		case inputArchive != "":
+			err = ingestArchive(inputArchive, add)
+		default:
+			err = ingestFile(inputFile, add)
+		}
+		if err != nil {
+			writer.Abort()
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Display resource counts
+		resourceCounts := kubernetes.GetResourceCounts(config)
+		fmt.Println("Resource counts:")
+		for kind, count := range resourceCounts {
+			fmt.Printf("  %s: %d\n", kind, count)
+		}
+		showFingerprint(kubernetes.FingerprintCluster(config))
+
+		if err := writer.Commit(config); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
 			os.Exit(1)
 		}
@@ -113,28 +138,117 @@ var ingestCmd = &cobra.Command{
 	},
 }
 
-// readJSON reads and validates the JSON file
-func readJSON(filePath string) ([]byte, error) {
-	data, err := os.ReadFile(filePath)
+// ingestFile reads a single JSON or YAML configuration file, passing each item to fn.
+// JSON is decoded one item at a time and YAML one document at a time, so
+// multi-gigabyte cluster dumps never have to be held in memory as raw bytes.
+func ingestFile(path string, fn kubernetes.ItemHandler) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve file path: %w", err)
+	}
+
+	// Check if file exists
+	fmt.Printf("Ingesting file: %s\n", absPath)
+	info, err := os.Stat(absPath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("file %s does not exist", absPath)
+	}
+
+	file, err := os.Open(absPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+	defer file.Close()
+
+	progress := newProgressReader(file, info.Size())
+	format, err := kubernetes.DecodeConfigStream(progress, func(item kubernetes.Item) error {
+		progress.ItemDone()
+		return fn(item)
+	})
+	progress.Finish()
+	if err != nil {
+		return fmt.Errorf("failed to parse Kubernetes configuration: %w", err)
+	}
+
+	fmt.Println("Successfully ingested Kubernetes configuration")
+	fmt.Printf("File size: %d bytes\n", info.Size())
+	fmt.Printf("Format: %s\n", format)
+	return nil
+}
+
+// ingestDirectory merges every manifest file found under a directory tree
+func ingestDirectory(dir string, fn kubernetes.ItemHandler) error {
+	absPath, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve directory path: %w", err)
+	}
+
+	fmt.Printf("Ingesting directory: %s\n", absPath)
+	info, err := os.Stat(absPath)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("directory %s does not exist", absPath)
 	}
 
-	// Validate JSON format
-	var jsonData interface{}
-	if err := json.Unmarshal(data, &jsonData); err != nil {
-		return nil, fmt.Errorf("invalid JSON format: %w", err)
+	result, err := kubernetes.LoadDirectory(absPath, fn)
+	if err != nil {
+		return fmt.Errorf("failed to load directory: %w", err)
+	}
+
+	return showLoadResult(result)
+}
+
+// ingestArchive merges every manifest file found in a tar, tar.gz or zip archive
+func ingestArchive(archive string, fn kubernetes.ItemHandler) error {
+	absPath, err := filepath.Abs(archive)
+	if err != nil {
+		return fmt.Errorf("failed to resolve archive path: %w", err)
+	}
+
+	fmt.Printf("Ingesting archive: %s\n", absPath)
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return fmt.Errorf("archive %s does not exist", absPath)
+	}
+
+	result, err := kubernetes.LoadArchive(absPath, fn)
+	if err != nil {
+		return fmt.Errorf("failed to load archive: %w", err)
+	}
+
+	return showLoadResult(result)
+}
+
+// showLoadResult summarises a directory or archive load, including files that were skipped
+func showLoadResult(result *kubernetes.LoadResult) error {
+	if result.Files == 0 {
+		return fmt.Errorf("no .json, .yaml or .yml files found")
+	}
+
+	if len(result.Skipped) > 0 {
+		fmt.Printf("Warning: skipped %d files that could not be parsed:\n", len(result.Skipped))
+		for _, skipped := range result.Skipped {
+			fmt.Printf("  - %v\n", skipped)
+		}
 	}
 
-	return data, nil
+	fmt.Println("Successfully ingested Kubernetes configuration")
+	fmt.Printf("Files read: %d (%d parsed)\n", result.Files, result.Files-len(result.Skipped))
+	fmt.Printf("Total size: %d bytes\n", result.Bytes)
+	fmt.Printf("Items merged: %d\n", result.Items)
+	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(ingestCmd)
-	ingestCmd.Flags().StringVarP(&inputFile, "file", "f", "", "JSON file containing Kubernetes cluster configuration (required)")
+	ingestCmd.Flags().StringVarP(&inputFile, "file", "f", "", "JSON or YAML file containing Kubernetes cluster configuration")
+	ingestCmd.Flags().StringVar(&inputDir, "dir", "", "Directory tree of JSON/YAML manifests to merge into one configuration")
+	ingestCmd.Flags().StringVar(&inputArchive, "archive", "", "Archive (.tar, .tar.gz, .tgz, .zip) of JSON/YAML manifests to merge into one configuration")
 	ingestCmd.Flags().StringVarP(&clusterName, "name", "n", "", "Name to identify the cluster configuration (defaults to timestamp)")
 	ingestCmd.Flags().StringVarP(&storageDir, "storage-dir", "s", "", "Directory to store parsed configurations (defaults to .eolas in home directory)")
 	ingestCmd.Flags().BoolVarP(&useHomeDir, "use-home", "", true, "Store configurations in .eolas directory in user's home directory")
 	ingestCmd.Flags().StringVar(&storageBackend, "backend", "file", "Storage backend to use (file, sqlite)")
-	ingestCmd.MarkFlagRequired("file")
+	ingestCmd.Flags().StringSliceVar(&ingestAllowedRegistries, "allowed-registries", nil, "Registries images may be pulled from, used by the stored image analysis (default: any)")
+	ingestCmd.Flags().StringVar(&ingestTargetVersion, "target-version", "", "Kubernetes version to plan an upgrade to, used by the stored deprecated API analysis (default: report all deprecations)")
+	ingestCmd.Flags().StringVar(&ingestHostPathCatalogue, "host-path-catalogue", "", "YAML or JSON host path rules used by the stored hostPath analysis (default: built-in catalogue)")
+	ingestCmd.MarkFlagsOneRequired("file", "dir", "archive")
+	ingestCmd.MarkFlagsMutuallyExclusive("file", "dir", "archive")
 }
\ No newline at end of file
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/raesene/eolas/pkg/kubernetes"
 	"github.com/raesene/eolas/pkg/storage"
 	"github.com/spf13/cobra"
 )
@@ -79,8 +80,8 @@ var listCmd = &cobra.Command{
 			}
 
 			fmt.Printf("Configuration history for '%s' (%s backend):\n", listConfigName, listStorageBackend)
-			fmt.Printf("%-36s %-20s %-15s %s\n", "ID", "TIMESTAMP", "RESOURCES", "DESCRIPTION")
-			fmt.Printf("%-36s %-20s %-15s %s\n", "--", "---------", "---------", "-----------")
+			fmt.Printf("%-36s %-20s %-15s %-30s %s\n", "ID", "TIMESTAMP", "RESOURCES", "CLUSTER", "DESCRIPTION")
+			fmt.Printf("%-36s %-20s %-15s %-30s %s\n", "--", "---------", "---------", "-------", "-----------")
 
 			for _, config := range history {
 				totalResources := 0
@@ -93,10 +94,11 @@ var listCmd = &cobra.Command{
 					description = "-"
 				}
 
-				fmt.Printf("%-36s %-20s %-15d %s\n", 
+				fmt.Printf("%-36s %-20s %-15d %-30s %s\n", 
 					config.ID, 
 					config.Timestamp.Format("2006-01-02 15:04:05"),
 					totalResources,
+					fingerprintSummary(config.Fingerprint),
 					description,
 				)
 			}
@@ -141,6 +143,7 @@ var listCmd = &cobra.Command{
 						totalResources,
 						len(history),
 					)
+					fmt.Printf("    Cluster: %s\n", fingerprintSummary(latest.Fingerprint))
 				} else {
 					fmt.Printf("  - %s (no versions found)\n", configName)
 				}
@@ -149,12 +152,27 @@ var listCmd = &cobra.Command{
 			// Simple listing for file backend
 			fmt.Printf("Stored configurations (%s backend) in %s:\n", listStorageBackend, storeDir)
 			for _, config := range configs {
-				fmt.Printf("  - %s\n", config)
+				// The file backend reads the fingerprint saved next to each configuration
+				metadata, err := store.GetConfigMetadata(config)
+				if err != nil {
+					fmt.Printf("  - %s\n", config)
+					continue
+				}
+				fmt.Printf("  - %s (%s)\n", config, fingerprintSummary(metadata.Fingerprint))
 			}
 		}
 	},
 }
 
+// fingerprintSummary summarises a stored fingerprint, which configurations saved
+// before fingerprinting do not have
+func fingerprintSummary(fingerprint *kubernetes.ClusterFingerprint) string {
+	if fingerprint == nil {
+		return "-"
+	}
+	return fingerprint.String()
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.Flags().StringVarP(&listStorageDir, "storage-dir", "s", "", "Directory where configurations are stored (defaults to .eolas in home directory)")
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/raesene/eolas/pkg/kubernetes"
+	"github.com/raesene/eolas/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pathsConfigName     string
+	pathsStorageDir     string
+	pathsUseHomeDir     bool
+	pathsStorageBackend string
+	pathsFormat         string
+	pathsOutputFile     string
+	pathsFullGraph      bool
+)
+
+// PathsData is the JSON output of the paths command
+type PathsData struct {
+	ConfigName string                  `json:"config_name"`
+	Paths      []kubernetes.AttackPath `json:"paths"`
+	Graph      kubernetes.AttackGraph  `json:"graph"`
+}
+
+var pathsCmd = &cobra.Command{
+	Use:   "paths",
+	Short: "Find attack paths from workloads to cluster-admin",
+	Long: `Find escalation chains from workloads to cluster-admin in a stored configuration.
+
+Privileged, hostPID and hostPath findings are combined with service accounts and
+RBAC bindings to show which findings actually chain into full cluster compromise, e.g.:
+
+  Deployment app/web -> ServiceAccount app/web -> (reads token secrets) ->
+  ServiceAccount kube-system/admin -> cluster-admin
+
+Output formats:
+- text: one chain per workload (default)
+- json: the paths and the graph they were found in
+- dot:  a Graphviz graph of the paths (render with: dot -Tsvg paths.dot -o paths.svg)`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if pathsConfigName == "" {
+			fmt.Println("Error: configuration name is required")
+			cmd.Help()
+			return
+		}
+
+		// Validate storage backend
+		if err := storage.ValidateBackend(pathsStorageBackend); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if pathsFormat != "text" && pathsFormat != "json" && pathsFormat != "dot" {
+			fmt.Fprintf(os.Stderr, "Error: format must be 'text', 'json' or 'dot'\n")
+			os.Exit(1)
+		}
+
+		// Determine storage directory
+		var storeDir string
+		if pathsStorageDir != "" {
+			storeDir = pathsStorageDir
+		} else if pathsUseHomeDir {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error determining home directory: %v\n", err)
+				os.Exit(1)
+			}
+			storeDir = filepath.Join(homeDir, ".eolas")
+		} else {
+			storeDir = ".eolas"
+		}
+
+		store, err := storage.NewStore(storage.StorageConfig{
+			Backend:    storage.Backend(pathsStorageBackend),
+			StorageDir: storeDir,
+			UseHomeDir: pathsUseHomeDir,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error accessing storage: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+
+		config, err := store.LoadConfig(pathsConfigName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration '%s': %v\n", pathsConfigName, err)
+			os.Exit(1)
+		}
+
+		graph := kubernetes.BuildAttackGraph(config)
+		paths := kubernetes.FindAttackPaths(graph)
+		if !pathsFullGraph {
+			graph = kubernetes.PathsGraph(graph, paths)
+		}
+
+		var outputData []byte
+		switch pathsFormat {
+		case "json":
+			outputData, err = json.MarshalIndent(PathsData{
+				ConfigName: pathsConfigName,
+				Paths:      paths,
+				Graph:      graph,
+			}, "", "  ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error generating JSON: %v\n", err)
+				os.Exit(1)
+			}
+			outputData = append(outputData, '\n')
+		case "dot":
+			outputData = []byte(formatPathsDOT(graph))
+		default:
+			outputData = []byte(formatPathsText(pathsConfigName, paths))
+		}
+
+		if pathsOutputFile == "" || pathsOutputFile == "-" {
+			os.Stdout.Write(outputData)
+			return
+		}
+
+		if err := os.WriteFile(pathsOutputFile, outputData, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Attack paths saved to: %s\n", pathsOutputFile)
+	},
+}
+
+// formatPathsText renders attack paths as one chain per workload
+func formatPathsText(configName string, paths []kubernetes.AttackPath) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Attack paths in configuration: %s\n\n", configName)
+
+	if len(paths) == 0 {
+		b.WriteString("No workload has a path to cluster-admin.\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Found %d workloads with a path to cluster-admin\n\n", len(paths))
+	for i, path := range paths {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, path.Start().Label)
+		for _, step := range path.Steps[1:] {
+			fmt.Fprintf(&b, "   -> %s\n", step.Node.Label)
+			fmt.Fprintf(&b, "      %s\n", step.Reason)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("Note: Each chain is the shortest route from the workload. Reading service account\n")
+	b.WriteString("tokens is only followed to token Secrets in the configuration, so snapshots without\n")
+	b.WriteString("Secrets show no such steps.\n")
+	return b.String()
+}
+
+// formatPathsDOT renders an attack graph in Graphviz DOT format
+func formatPathsDOT(graph kubernetes.AttackGraph) string {
+	shapes := map[string]string{
+		kubernetes.PathNodeWorkload:       "box",
+		kubernetes.PathNodeServiceAccount: "ellipse",
+		kubernetes.PathNodeHost:           "house",
+		kubernetes.PathNodeClusterAdmin:   "doubleoctagon",
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph attack_paths {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"Helvetica\"];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+	for _, node := range graph.Nodes {
+		attrs := fmt.Sprintf("label=%q, shape=%s", node.Label, shapes[node.Type])
+		if node.Type == kubernetes.PathNodeClusterAdmin {
+			attrs += ", style=filled, fillcolor=\"#e74c3c\", fontcolor=white"
+		}
+		fmt.Fprintf(&b, "  %q [%s];\n", node.ID, attrs)
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Reason)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(pathsCmd)
+	pathsCmd.Flags().StringVarP(&pathsConfigName, "name", "n", "", "Name of the cluster configuration to analyze (required)")
+	pathsCmd.Flags().StringVarP(&pathsStorageDir, "storage-dir", "s", "", "Directory where configurations are stored (defaults to .eolas in home directory)")
+	pathsCmd.Flags().BoolVarP(&pathsUseHomeDir, "use-home", "", true, "Use .eolas directory in user's home directory")
+	pathsCmd.Flags().StringVar(&pathsStorageBackend, "backend", "file", "Storage backend to use (file, sqlite)")
+	pathsCmd.Flags().StringVarP(&pathsFormat, "format", "f", "text", "Output format (text, json, dot)")
+	pathsCmd.Flags().StringVarP(&pathsOutputFile, "output", "o", "", "Output file (default is stdout)")
+	pathsCmd.Flags().BoolVar(&pathsFullGraph, "all", false, "Include the whole graph in json and dot output, not only the edges on attack paths")
+}
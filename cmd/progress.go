@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// progressReader wraps a reader and reports read progress and decoded item counts on stderr
+type progressReader struct {
+	reader    io.Reader
+	total     int64
+	read      int64
+	items     int
+	enabled   bool
+	lastShown time.Time
+}
+
+// newProgressReader creates a progress reporter for a reader of known total size.
+// Progress is only drawn when stderr is a terminal so redirected output stays clean.
+func newProgressReader(reader io.Reader, total int64) *progressReader {
+	enabled := false
+	if info, err := os.Stderr.Stat(); err == nil {
+		enabled = info.Mode()&os.ModeCharDevice != 0
+	}
+
+	return &progressReader{
+		reader:  reader,
+		total:   total,
+		enabled: enabled,
+	}
+}
+
+// Read implements io.Reader
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.reader.Read(buf)
+	p.read += int64(n)
+	p.show(false)
+	return n, err
+}
+
+// ItemDone records that one more item has been decoded
+func (p *progressReader) ItemDone() {
+	p.items++
+	p.show(false)
+}
+
+// Finish draws the final progress line and moves to a new line
+func (p *progressReader) Finish() {
+	p.show(true)
+	if p.enabled {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// show redraws the progress line at most a few times per second
+func (p *progressReader) show(force bool) {
+	if !p.enabled {
+		return
+	}
+	if !force && time.Since(p.lastShown) < 200*time.Millisecond {
+		return
+	}
+	p.lastShown = time.Now()
+
+	percent := 0.0
+	if p.total > 0 {
+		percent = float64(p.read) / float64(p.total) * 100
+	}
+	fmt.Fprintf(os.Stderr, "\rParsing: %s / %s (%.0f%%), %d items", formatBytes(p.read), formatBytes(p.total), percent, p.items)
+}
+
+// formatBytes renders a byte count using binary units
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -16,7 +16,7 @@ var rootCmd = &cobra.Command{
 	Use:   "eolas",
 	Short: "Eolas is a command line utility for analyzing Kubernetes clusters",
 	Long: `Eolas is a command line utility for analyzing Kubernetes cluster configurations.
-It ingests JSON files containing Kubernetes resources and provides analysis capabilities.
+It ingests JSON or YAML files containing Kubernetes resources and provides analysis capabilities.
 
 For more information visit: https://github.com/raesene/eolas`,
 	Run: func(cmd *cobra.Command, args []string) {
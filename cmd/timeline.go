@@ -28,6 +28,7 @@ This command creates an interactive HTML report showing:
 - Configuration evolution timeline
 - Resource count trends over time  
 - Security posture changes
+- Findings introduced and resolved in each version, with time to remediate
 - Current vs previous snapshot comparison
 
 Note: Timeline reports are only available for SQLite backend.`,
@@ -110,6 +111,13 @@ Note: Timeline reports are only available for SQLite backend.`,
 			os.Exit(1)
 		}
 
+		// Get the lifecycle of each finding across the versions
+		lifecycle, err := store.GetFindingLifecycle(timelineConfigName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting finding lifecycle: %v\n", err)
+			os.Exit(1)
+		}
+
 		// Create timeline formatter
 		formatter, err := output.NewTimelineFormatter()
 		if err != nil {
@@ -118,7 +126,7 @@ Note: Timeline reports are only available for SQLite backend.`,
 		}
 
 		// Generate timeline HTML
-		htmlContent, err := formatter.GenerateTimelineHTML(timelineConfigName, history, securityHistory)
+		htmlContent, err := formatter.GenerateTimelineHTML(timelineConfigName, history, securityHistory, lifecycle)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating timeline report: %v\n", err)
 			os.Exit(1)
@@ -152,6 +160,7 @@ Note: Timeline reports are only available for SQLite backend.`,
 			history[len(history)-1].Timestamp.Format("Jan 2, 2006"))
 		fmt.Printf("- Resource evolution trends\n")
 		fmt.Printf("- Security posture changes\n")
+		fmt.Printf("- Findings introduced and resolved, with time to remediate\n")
 		fmt.Printf("- Interactive timeline with detailed changes\n")
 	},
 }
@@ -0,0 +1,364 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity ranks how serious a finding is
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+	SeverityInfo     Severity = "info"
+)
+
+// Rank orders severities from info (0) to critical (4)
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 4
+	case SeverityHigh:
+		return 3
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IDs of the built-in analyzers
+const (
+	CheckPrivileged      = "privileged"
+	CheckCapabilities    = "capabilities"
+	CheckHostNamespaces  = "host-namespaces"
+	CheckHostPath        = "host-path"
+	CheckPSS             = "pss"
+	CheckRBAC            = "rbac"
+	CheckNetworkPolicy   = "network-policy"
+	CheckExposure        = "exposure"
+	CheckImages          = "images"
+	CheckResources       = "resources"
+	CheckDeprecatedAPIs  = "deprecated-apis"
+	CheckReliability     = "reliability"
+	CheckReferences      = "references"
+	CheckSecrets         = "secrets"
+	CheckServiceAccounts = "service-accounts"
+	CheckWebhooks        = "webhooks"
+	CheckScheduling      = "scheduling"
+)
+
+// Finding is a single result reported by an analyzer
+type Finding struct {
+	AnalyzerID string
+	Severity   Severity
+	Namespace  string
+	Kind       string
+	Name       string
+	Container  string
+	Rule       string // identifies the issue within the object, e.g. a PSS check or a host path
+	Details    string
+}
+
+// Fingerprint identifies a finding across snapshots. It hashes the analyzer, the
+// object, its container and the rule but not the details, so a finding keeps its
+// fingerprint while it is open even as its details change, and one that is fixed and
+// comes back gets the same fingerprint again.
+func (f Finding) Fingerprint() string {
+	key := strings.Join([]string{f.AnalyzerID, f.Kind, f.Namespace, f.Name, f.Container, f.Rule}, "\x00")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Subject names the object a finding is about, e.g. "Deployment app/web (container nginx)"
+func (f Finding) Subject() string {
+	subject := f.Name
+	if f.Namespace != "" && f.Kind != "Namespace" {
+		subject = f.Namespace + "/" + f.Name
+	}
+	if f.Kind != "" {
+		subject = strings.TrimSpace(f.Kind + " " + subject)
+	}
+	if f.Container != "" {
+		subject += " (container " + f.Container + ")"
+	}
+	return subject
+}
+
+// String formats the finding on one line, e.g. "[high] Deployment app/web: runs as root"
+func (f Finding) String() string {
+	return fmt.Sprintf("[%s] %s: %s", f.Severity, f.Subject(), f.Details)
+}
+
+// Analyzer is a check that can be run against a cluster configuration.
+// Analyzers are added with Register and are then picked up by analyze, export,
+// comparison, stored security analysis and the HTML report.
+type Analyzer interface {
+	// ID is a short, unique, flag-friendly identifier (e.g. "privileged")
+	ID() string
+	// Name is the human readable title of the check
+	Name() string
+	// Description explains what the check looks for and why it matters
+	Description() string
+	// Severity is the default severity of the check's findings
+	Severity() Severity
+	// Analyze runs the check with the given options and returns its findings
+	Analyze(config *ClusterConfig, opts Options) []Finding
+}
+
+// funcAnalyzer adapts a plain function to the Analyzer interface
+type funcAnalyzer struct {
+	id          string
+	name        string
+	description string
+	severity    Severity
+	analyze     func(config *ClusterConfig, opts Options) []Finding
+}
+
+func (a *funcAnalyzer) ID() string          { return a.id }
+func (a *funcAnalyzer) Name() string        { return a.name }
+func (a *funcAnalyzer) Description() string { return a.description }
+func (a *funcAnalyzer) Severity() Severity  { return a.severity }
+
+func (a *funcAnalyzer) Analyze(config *ClusterConfig, opts Options) []Finding {
+	return a.analyze(config, opts)
+}
+
+// NewAnalyzer creates an Analyzer from a function, which is the simplest way to
+// add a check that takes no options to the registry
+func NewAnalyzer(id, name, description string, severity Severity, analyze func(config *ClusterConfig) []Finding) Analyzer {
+	return NewConfigurableAnalyzer(id, name, description, severity, func(config *ClusterConfig, _ Options) []Finding {
+		return analyze(config)
+	})
+}
+
+// NewConfigurableAnalyzer creates an Analyzer from a function that reads Options
+func NewConfigurableAnalyzer(id, name, description string, severity Severity, analyze func(config *ClusterConfig, opts Options) []Finding) Analyzer {
+	return &funcAnalyzer{
+		id:          id,
+		name:        name,
+		description: description,
+		severity:    severity,
+		analyze:     analyze,
+	}
+}
+
+var (
+	analyzers     []Analyzer
+	analyzersByID = make(map[string]Analyzer)
+)
+
+// Register adds an analyzer to the registry. It panics if an analyzer with the
+// same ID is already registered.
+func Register(a Analyzer) {
+	if _, exists := analyzersByID[a.ID()]; exists {
+		panic(fmt.Sprintf("kubernetes: analyzer %q registered twice", a.ID()))
+	}
+	analyzers = append(analyzers, a)
+	analyzersByID[a.ID()] = a
+}
+
+// Analyzers returns all registered analyzers in registration order
+func Analyzers() []Analyzer {
+	return append([]Analyzer(nil), analyzers...)
+}
+
+// GetAnalyzer looks up a registered analyzer by ID
+func GetAnalyzer(id string) (Analyzer, bool) {
+	a, ok := analyzersByID[id]
+	return a, ok
+}
+
+// AnalyzerIDs returns the IDs of all registered analyzers in registration order
+func AnalyzerIDs() []string {
+	ids := make([]string, len(analyzers))
+	for i, a := range analyzers {
+		ids[i] = a.ID()
+	}
+	return ids
+}
+
+// RunAnalyzer runs a single analyzer, filling in default AnalyzerID and Severity
+// and sorting the findings so results are stable between runs
+func RunAnalyzer(a Analyzer, config *ClusterConfig, opts Options) []Finding {
+	findings := a.Analyze(config, opts)
+	for i := range findings {
+		if findings[i].AnalyzerID == "" {
+			findings[i].AnalyzerID = a.ID()
+		}
+		if findings[i].Severity == "" {
+			findings[i].Severity = a.Severity()
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		fi, fj := findings[i], findings[j]
+		if fi.Namespace != fj.Namespace {
+			return fi.Namespace < fj.Namespace
+		}
+		if fi.Kind != fj.Kind {
+			return fi.Kind < fj.Kind
+		}
+		if fi.Name != fj.Name {
+			return fi.Name < fj.Name
+		}
+		return fi.Container < fj.Container
+	})
+
+	return findings
+}
+
+// RunAnalyzers runs every registered analyzer with the same options and returns the
+// findings keyed by analyzer ID
+func RunAnalyzers(config *ClusterConfig, opts Options) map[string][]Finding {
+	results := make(map[string][]Finding, len(analyzers))
+	for _, a := range analyzers {
+		results[a.ID()] = RunAnalyzer(a, config, opts)
+	}
+	return results
+}
+
+func init() {
+	Register(NewAnalyzer(CheckPrivileged, "Privileged Containers",
+		"Containers running with a privileged security context, which gives them full access to the host's kernel capabilities and devices",
+		SeverityCritical, privilegedFindings))
+	Register(NewAnalyzer(CheckCapabilities, "Capability Containers",
+		"Containers that add Linux capabilities such as SYS_ADMIN, NET_ADMIN or SYS_PTRACE",
+		SeverityMedium, capabilityFindings))
+	Register(NewAnalyzer(CheckHostNamespaces, "Host Namespace Usage",
+		"Workloads sharing the host's PID, IPC or network namespaces or binding host ports",
+		SeverityHigh, hostNamespaceFindings))
+	Register(NewConfigurableAnalyzer(CheckHostPath, "Host Path Volumes",
+		"Workloads mounting directories from the node's filesystem with hostPath volumes, rated against a catalogue of sensitive host paths such as runtime sockets, /etc and /var/lib/kubelet (--host-path-catalogue)",
+		SeverityHigh, hostPathFindings))
+	Register(NewAnalyzer(CheckPSS, "Pod Security Standards",
+		"Workloads that would be rejected by the baseline or restricted Pod Security Standards profiles",
+		SeverityHigh, pssFindings))
+	Register(NewAnalyzer(CheckRBAC, "RBAC Privileges",
+		"Users, groups and service accounts bound to dangerous permissions such as cluster-admin, wildcards, escalate/bind/impersonate, pods/exec, secrets read or nodes/proxy",
+		SeverityHigh, rbacFindings))
+	Register(NewAnalyzer(CheckNetworkPolicy, "Network Policy Coverage",
+		"Namespaces without default-deny NetworkPolicies, workloads no policy selects, policies that select nothing and workloads reachable from all namespaces",
+		SeverityMedium, networkPolicyFindings))
+	Register(NewConfigurableAnalyzer(CheckExposure, "External Exposure",
+		"NodePort, LoadBalancer and externalIPs Services, Ingresses and HTTPRoutes reachable from outside the cluster, and the workloads behind them",
+		SeverityLow, exposureFindings))
+	Register(NewConfigurableAnalyzer(CheckImages, "Image Hygiene",
+		"Images using the latest tag or no tag, not pinned by digest, from registries outside the allowlist, or running at different tags across the cluster",
+		SeverityLow, imageFindings))
+	Register(NewAnalyzer(CheckResources, "Resource Requests and Limits",
+		"Containers missing CPU or memory requests or limits, limits far above requests, BestEffort workloads and namespaces without a LimitRange or ResourceQuota",
+		SeverityLow, resourceFindings))
+	Register(NewConfigurableAnalyzer(CheckDeprecatedAPIs, "Deprecated APIs",
+		"Resources using API versions that are deprecated or removed in the target Kubernetes version (--target-version), including the version in their last-applied-configuration",
+		SeverityMedium, deprecatedAPIFindings))
+	Register(NewAnalyzer(CheckReliability, "Reliability",
+		"Single-replica Deployments and StatefulSets without a PodDisruptionBudget, PDBs that block all evictions, containers without readiness or liveness probes and replicas without spread constraints or anti-affinity",
+		SeverityLow, reliabilityFindings))
+	Register(NewAnalyzer(CheckReferences, "Dangling References",
+		"Services selecting no pods, Ingress backends, pod ConfigMaps, Secrets, PVCs and ServiceAccounts, bindings' roles and owner references that point to objects missing from the configuration",
+		SeverityMedium, referenceFindings))
+	Register(NewAnalyzer(CheckSecrets, "Secret Exposure",
+		"Likely credentials such as AWS keys, JWTs, private keys and high-entropy strings in env values, ConfigMap data and annotations, and Secrets passed to containers as environment variables",
+		SeverityHigh, secretFindings))
+	Register(NewAnalyzer(CheckServiceAccounts, "Service Account Tokens",
+		"Pods automounting the token of a service account with RBAC bindings, long-lived kubernetes.io/service-account-token Secrets and workloads running as the default service account",
+		SeverityHigh, serviceAccountFindings))
+	Register(NewConfigurableAnalyzer(CheckWebhooks, "Admission Webhooks",
+		"Security webhooks with failurePolicy Ignore or namespaceSelectors exempting most namespaces, webhooks intercepting kube-system, long timeouts and expired or expiring caBundle certificates",
+		SeverityMedium, webhookFindings))
+	Register(NewAnalyzer(CheckScheduling, "Scheduling Escape",
+		"Workloads that can land on control plane nodes through control plane or catch-all tolerations, nodeName pins or nodeSelectors and node affinity for control plane labels, confirmed against the snapshot's Nodes",
+		SeverityHigh, schedulingFindings))
+}
+
+// privilegedFindings adapts GetPrivilegedContainers to findings
+func privilegedFindings(config *ClusterConfig) []Finding {
+	var findings []Finding
+	for _, pc := range GetPrivilegedContainers(config) {
+		findings = append(findings, Finding{
+			Namespace: pc.Namespace,
+			Kind:      pc.Kind,
+			Name:      pc.PodName,
+			Container: pc.Name,
+			Details:   securityContextDetails("Privileged: true", pc.Type, pc.Settings),
+		})
+	}
+	return findings
+}
+
+// capabilityFindings adapts GetCapabilityContainers to findings
+func capabilityFindings(config *ClusterConfig) []Finding {
+	var findings []Finding
+	for _, cc := range GetCapabilityContainers(config) {
+		findings = append(findings, Finding{
+			Namespace: cc.Namespace,
+			Kind:      cc.Kind,
+			Name:      cc.PodName,
+			Container: cc.Name,
+			Details:   securityContextDetails("Capabilities: "+strings.Join(cc.Capabilities, ", "), cc.Type, cc.Settings),
+		})
+	}
+	return findings
+}
+
+// hostNamespaceFindings adapts GetHostNamespaceWorkloads to findings, one per shared
+// host namespace and one per host port, on the container binding it
+func hostNamespaceFindings(config *ClusterConfig) []Finding {
+	var findings []Finding
+	for _, hn := range GetHostNamespaceWorkloads(config) {
+		finding := Finding{Namespace: hn.Namespace, Kind: hn.Kind, Name: hn.Name}
+		for _, shared := range []struct {
+			enabled bool
+			rule    string
+			details string
+		}{
+			{hn.HostPID, "hostPID", "HostPID: shares the host PID namespace"},
+			{hn.HostIPC, "hostIPC", "HostIPC: shares the host IPC namespace"},
+			{hn.HostNetwork, "hostNetwork", "HostNetwork: shares the host network namespace"},
+		} {
+			if shared.enabled {
+				finding.Rule, finding.Details = shared.rule, shared.details
+				findings = append(findings, finding)
+			}
+		}
+		for i, port := range hn.HostPorts {
+			finding.Container = hn.PortContainers[i]
+			finding.Rule = fmt.Sprintf("hostPort %d", port)
+			finding.Details = fmt.Sprintf("HostPort: binds port %d on the node", port)
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// hostPathFindings adapts GetHostPathVolumes to findings, one per path with the
+// severity the host path catalogue gives it
+func hostPathFindings(config *ClusterConfig, opts Options) []Finding {
+	var findings []Finding
+	for _, hp := range GetHostPathVolumes(config, opts) {
+		for i, path := range hp.HostPaths {
+			access := "writable"
+			if hp.ReadOnly[i] {
+				access = "read-only"
+			}
+			findings = append(findings, Finding{
+				Namespace: hp.Namespace,
+				Kind:      hp.Kind,
+				Name:      hp.Name,
+				Severity:  hp.Severities[i],
+				Rule:      path,
+				Details:   fmt.Sprintf("Path: %s (%s): %s", path, access, hp.Reasons[i]),
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,363 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// nodePoolLabels are the node labels that name a node pool, in order of preference
+var nodePoolLabels = []string{
+	"cloud.google.com/gke-nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+	"karpenter.sh/nodepool",
+	"node.kubernetes.io/instance-type",
+}
+
+// Taint is a node taint that repels pods without a matching toleration
+type Taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+// String formats a taint the way kubectl does (key=value:effect)
+func (t Taint) String() string {
+	if t.Value == "" {
+		return t.Key + ":" + t.Effect
+	}
+	return fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+}
+
+// nodeSpec and nodeStatus are the parts of a Node used for capacity analysis
+type nodeSpec struct {
+	Unschedulable bool    `json:"unschedulable,omitempty"`
+	Taints        []Taint `json:"taints,omitempty"`
+}
+
+type nodeStatus struct {
+	Capacity    map[string]Quantity `json:"capacity,omitempty"`
+	Allocatable map[string]Quantity `json:"allocatable,omitempty"`
+}
+
+// CapacityUsage is allocatable CPU (cores) and memory (bytes) and the requests and
+// limits of the pods placed against it
+type CapacityUsage struct {
+	CPUAllocatable    float64
+	CPURequests       float64
+	CPULimits         float64
+	MemoryAllocatable float64
+	MemoryRequests    float64
+	MemoryLimits      float64
+	Pods              int
+	// Ratios of requests and limits to allocatable; a limit ratio above 1 is overcommitted
+	CPURequestRatio    float64
+	CPULimitRatio      float64
+	MemoryRequestRatio float64
+	MemoryLimitRatio   float64
+}
+
+// add accumulates another usage into u
+func (u *CapacityUsage) add(other CapacityUsage) {
+	u.CPUAllocatable += other.CPUAllocatable
+	u.CPURequests += other.CPURequests
+	u.CPULimits += other.CPULimits
+	u.MemoryAllocatable += other.MemoryAllocatable
+	u.MemoryRequests += other.MemoryRequests
+	u.MemoryLimits += other.MemoryLimits
+	u.Pods += other.Pods
+}
+
+// computeRatios fills in the request and limit ratios
+func (u *CapacityUsage) computeRatios() {
+	ratio := func(used, allocatable float64) float64 {
+		if allocatable == 0 {
+			return 0
+		}
+		return used / allocatable
+	}
+	u.CPURequestRatio = ratio(u.CPURequests, u.CPUAllocatable)
+	u.CPULimitRatio = ratio(u.CPULimits, u.CPUAllocatable)
+	u.MemoryRequestRatio = ratio(u.MemoryRequests, u.MemoryAllocatable)
+	u.MemoryLimitRatio = ratio(u.MemoryLimits, u.MemoryAllocatable)
+}
+
+// NodeCapacity is the allocation of a single node
+type NodeCapacity struct {
+	Name          string
+	Pool          string
+	Unschedulable bool
+	Taints        []string
+	CapacityUsage
+}
+
+// NodePoolCapacity is the combined allocation of the nodes in a pool
+type NodePoolCapacity struct {
+	Name  string
+	Nodes int
+	CapacityUsage
+}
+
+// UnschedulablePod is a workload whose pods fit no node by nodeSelector and taints
+type UnschedulablePod struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Reason    string
+}
+
+// CapacityReport is the CPU and memory allocation of a cluster's nodes
+type CapacityReport struct {
+	Nodes         []NodeCapacity
+	Pools         []NodePoolCapacity
+	Total         CapacityUsage
+	Unschedulable []UnschedulablePod
+}
+
+// GetCapacityReport computes per-node and per-pool CPU and memory allocation from
+// Node status and the requests and limits of the pods scheduled to each node, and
+// finds workloads whose nodeSelector and tolerations match no node
+func GetCapacityReport(config *ClusterConfig) CapacityReport {
+	var report CapacityReport
+	nodes := make(map[string]*NodeCapacity)
+	labels := make(map[string]map[string]string)
+	taints := make(map[string][]Taint)
+	var order []string
+
+	for _, item := range config.Items {
+		if item.Kind != "Node" {
+			continue
+		}
+		var spec nodeSpec
+		var status nodeStatus
+		decodeInto(item.Spec, &spec)
+		decodeInto(item.Status, &status)
+
+		node := &NodeCapacity{
+			Name:          item.Metadata.Name,
+			Pool:          nodePool(item.Metadata.Labels),
+			Unschedulable: spec.Unschedulable,
+		}
+		allocatable := status.Allocatable
+		if len(allocatable) == 0 {
+			allocatable = status.Capacity
+		}
+		node.CPUAllocatable, _ = allocatable["cpu"].Value()
+		node.MemoryAllocatable, _ = allocatable["memory"].Value()
+		for _, taint := range spec.Taints {
+			node.Taints = append(node.Taints, taint.String())
+		}
+
+		nodes[node.Name] = node
+		labels[node.Name] = item.Metadata.Labels
+		taints[node.Name] = spec.Taints
+		order = append(order, node.Name)
+	}
+
+	// Requests and limits of the running pods on each node
+	for _, item := range config.Items {
+		if item.Kind != "Pod" || !podIsActive(item) {
+			continue
+		}
+		template, ok := GetPodTemplate(item)
+		if !ok {
+			continue
+		}
+		node, ok := nodes[template.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		usage := PodResourceUsage(template.Spec)
+		usage.Pods = 1
+		node.CapacityUsage.add(usage)
+	}
+
+	pools := make(map[string]*NodePoolCapacity)
+	var poolOrder []string
+	sort.Strings(order)
+	for _, name := range order {
+		node := nodes[name]
+		node.computeRatios()
+		report.Nodes = append(report.Nodes, *node)
+
+		pool, ok := pools[node.Pool]
+		if !ok {
+			pool = &NodePoolCapacity{Name: node.Pool}
+			pools[node.Pool] = pool
+			poolOrder = append(poolOrder, node.Pool)
+		}
+		pool.Nodes++
+		pool.CapacityUsage.add(node.CapacityUsage)
+		report.Total.add(node.CapacityUsage)
+	}
+	sort.Strings(poolOrder)
+	for _, name := range poolOrder {
+		pools[name].computeRatios()
+		report.Pools = append(report.Pools, *pools[name])
+	}
+	report.Total.computeRatios()
+
+	// Workloads that fit no node; only meaningful when the snapshot includes nodes
+	if len(order) == 0 {
+		return report
+	}
+	for _, template := range GetPodTemplates(config) {
+		if template.IsManaged() || (template.Kind == "Pod" && template.Spec.NodeName != "") {
+			continue // owned by a controller, or already scheduled
+		}
+
+		var reasons []string
+		fits := false
+		for _, name := range order {
+			reason := nodeRejects(template.Spec, nodes[name].Unschedulable, labels[name], taints[name])
+			if reason == "" {
+				fits = true
+				break
+			}
+			reasons = appendUnique(reasons, reason)
+		}
+		if !fits {
+			report.Unschedulable = append(report.Unschedulable, UnschedulablePod{
+				Kind:      template.Kind,
+				Name:      template.Name,
+				Namespace: namespaceOrDefault(template.Namespace),
+				Reason:    strings.Join(reasons, "; "),
+			})
+		}
+	}
+
+	return report
+}
+
+// nodePool returns the node pool named by a node's labels
+func nodePool(labels map[string]string) string {
+	for _, label := range nodePoolLabels {
+		if pool := labels[label]; pool != "" {
+			return pool
+		}
+	}
+	return "default"
+}
+
+// podIsActive reports whether a pod still holds its resources on a node
+func podIsActive(item Item) bool {
+	status, ok := item.Status.(map[string]interface{})
+	if !ok {
+		return true
+	}
+	phase, _ := status["phase"].(string)
+	return phase != "Succeeded" && phase != "Failed"
+}
+
+// PodResourceUsage returns the CPU and memory requests and limits of a pod. Like the
+// scheduler, it uses the larger of the sum of the containers and the largest init
+// container, and counts a limit without a request as the request.
+func PodResourceUsage(spec PodSpec) CapacityUsage {
+	var usage, initUsage CapacityUsage
+	for _, container := range spec.Containers {
+		cpuRequest, cpuLimit, memoryRequest, memoryLimit := containerQuantities(container)
+		usage.CPURequests += cpuRequest
+		usage.CPULimits += cpuLimit
+		usage.MemoryRequests += memoryRequest
+		usage.MemoryLimits += memoryLimit
+	}
+	for _, container := range spec.InitContainers {
+		cpuRequest, cpuLimit, memoryRequest, memoryLimit := containerQuantities(container)
+		initUsage.CPURequests = max(initUsage.CPURequests, cpuRequest)
+		initUsage.CPULimits = max(initUsage.CPULimits, cpuLimit)
+		initUsage.MemoryRequests = max(initUsage.MemoryRequests, memoryRequest)
+		initUsage.MemoryLimits = max(initUsage.MemoryLimits, memoryLimit)
+	}
+
+	usage.CPURequests = max(usage.CPURequests, initUsage.CPURequests)
+	usage.CPULimits = max(usage.CPULimits, initUsage.CPULimits)
+	usage.MemoryRequests = max(usage.MemoryRequests, initUsage.MemoryRequests)
+	usage.MemoryLimits = max(usage.MemoryLimits, initUsage.MemoryLimits)
+	return usage
+}
+
+// containerQuantities returns a container's CPU and memory requests and limits
+func containerQuantities(container Container) (cpuRequest, cpuLimit, memoryRequest, memoryLimit float64) {
+	requests, limits := container.Resources.Requests, container.Resources.Limits
+	cpuLimit, _ = limits["cpu"].Value()
+	memoryLimit, _ = limits["memory"].Value()
+
+	cpuRequest = cpuLimit
+	if request, ok := requests["cpu"]; ok {
+		cpuRequest, _ = request.Value()
+	}
+	memoryRequest = memoryLimit
+	if request, ok := requests["memory"]; ok {
+		memoryRequest, _ = request.Value()
+	}
+	return cpuRequest, cpuLimit, memoryRequest, memoryLimit
+}
+
+// nodeRejects returns why a pod cannot schedule onto a node, or "" if it can
+func nodeRejects(spec PodSpec, unschedulable bool, labels map[string]string, taints []Taint) string {
+	for key, value := range spec.NodeSelector {
+		if labels[key] != value {
+			return fmt.Sprintf("no node matches nodeSelector %s=%s", key, value)
+		}
+	}
+
+	if unschedulable && !tolerates(spec.Tolerations, Taint{Key: "node.kubernetes.io/unschedulable", Effect: "NoSchedule"}) {
+		return "node cordoned"
+	}
+
+	for _, taint := range taints {
+		if taint.Effect == "PreferNoSchedule" {
+			continue
+		}
+		if !tolerates(spec.Tolerations, taint) {
+			return fmt.Sprintf("untolerated taint %s", taint)
+		}
+	}
+	return ""
+}
+
+// tolerates reports whether any toleration matches a taint
+func tolerates(tolerations []Toleration, taint Taint) bool {
+	for _, t := range tolerations {
+		if t.Effect != "" && t.Effect != taint.Effect {
+			continue
+		}
+		if t.Key == "" && t.Operator == "Exists" {
+			return true
+		}
+		if t.Key != taint.Key {
+			continue
+		}
+		if t.Operator == "Exists" || t.Value == taint.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatCPU formats a number of cores, e.g. 0.25 as "250m" and 4 as "4"
+func FormatCPU(cores float64) string {
+	if cores < 1 && cores > 0 {
+		return fmt.Sprintf("%.0fm", cores*1000)
+	}
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", cores), "0"), ".")
+}
+
+// FormatMemory formats a number of bytes with a binary suffix, e.g. "1.5Gi"
+func FormatMemory(bytes float64) string {
+	for _, unit := range []string{"Ti", "Gi", "Mi", "Ki"} {
+		multiplier := quantitySuffixes[unit]
+		if bytes >= multiplier {
+			value := strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.1f", bytes/multiplier), "0"), ".")
+			return value + unit
+		}
+	}
+	return fmt.Sprintf("%.0f", bytes)
+}
+
+// FormatRatio formats a usage ratio as a percentage
+func FormatRatio(ratio float64) string {
+	return fmt.Sprintf("%.0f%%", ratio*100)
+}
@@ -0,0 +1,129 @@
+package kubernetes
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Format identifies the serialization format of configuration data
+type Format string
+
+const (
+	// FormatJSON is a JSON document (a List or a single object)
+	FormatJSON Format = "json"
+	// FormatYAML is one or more YAML documents separated by "---"
+	FormatYAML Format = "yaml"
+)
+
+// DetectFormat guesses whether data is JSON or YAML by looking at the first
+// significant character. Anything that does not start like a JSON value is
+// treated as YAML.
+func DetectFormat(data []byte) Format {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// splitYAMLDocuments splits a multi-document YAML stream on "---" separators
+func splitYAMLDocuments(data []byte) [][]byte {
+	var documents [][]byte
+	var current bytes.Buffer
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if isDocumentSeparator(line) {
+			documents = append(documents, append([]byte(nil), current.Bytes()...))
+			current.Reset()
+			continue
+		}
+		current.WriteString(line)
+		current.WriteByte('\n')
+	}
+	documents = append(documents, current.Bytes())
+
+	return documents
+}
+
+// isDocumentSeparator reports whether a line starts a new YAML document
+func isDocumentSeparator(line string) bool {
+	if !strings.HasPrefix(line, "---") {
+		return false
+	}
+	rest := strings.TrimSpace(line[3:])
+	return rest == "" || strings.HasPrefix(rest, "#")
+}
+
+// appendDocument adds the resources in a single JSON document to the configuration.
+// A document may be a List (anything with an items array), a bare object or
+// a JSON array of objects.
+func appendDocument(config *ClusterConfig, data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+
+	if trimmed[0] == '[' {
+		var items []Item
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if item.Kind != "" {
+				config.Items = append(config.Items, item)
+			}
+		}
+		return nil
+	}
+
+	var probe struct {
+		Kind  string          `json:"kind"`
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return err
+	}
+
+	if probe.Items != nil || strings.HasSuffix(probe.Kind, "List") {
+		var list ClusterConfig
+		if err := json.Unmarshal(trimmed, &list); err != nil {
+			return err
+		}
+		config.Items = append(config.Items, list.Items...)
+		return nil
+	}
+
+	// Skip documents that are not Kubernetes objects (e.g. kustomize patches)
+	if probe.Kind == "" {
+		return nil
+	}
+
+	var item Item
+	if err := json.Unmarshal(trimmed, &item); err != nil {
+		return err
+	}
+	config.Items = append(config.Items, item)
+	return nil
+}
+
+// parseYAMLConfig converts each YAML document to JSON and collects its resources
+func parseYAMLConfig(data []byte, config *ClusterConfig) error {
+	for i, document := range splitYAMLDocuments(data) {
+		jsonData, err := yaml.YAMLToJSON(document)
+		if err != nil {
+			return fmt.Errorf("document %d: %w", i+1, err)
+		}
+		if err := appendDocument(config, jsonData); err != nil {
+			return fmt.Errorf("document %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// lastAppliedAnnotation holds the manifest last applied with kubectl apply
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// APIDeprecation is a deprecated API version of a kind and the release it is removed in
+type APIDeprecation struct {
+	APIVersion   string
+	Kind         string
+	DeprecatedIn string
+	RemovedIn    string
+	Replacement  string // API version to migrate to, or what replaces the kind
+}
+
+// APIDeprecations is the table of deprecated and removed built-in APIs
+var APIDeprecations = []APIDeprecation{
+	// Removed in 1.16
+	{"extensions/v1beta1", "Deployment", "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", "DaemonSet", "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", "ReplicaSet", "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", "NetworkPolicy", "1.9", "1.16", "networking.k8s.io/v1"},
+	{"extensions/v1beta1", "PodSecurityPolicy", "1.11", "1.16", "policy/v1beta1"},
+	{"apps/v1beta1", "Deployment", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta1", "StatefulSet", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "Deployment", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "DaemonSet", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "ReplicaSet", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "StatefulSet", "1.9", "1.16", "apps/v1"},
+
+	// Removed in 1.22
+	{"extensions/v1beta1", "Ingress", "1.14", "1.22", "networking.k8s.io/v1"},
+	{"networking.k8s.io/v1beta1", "Ingress", "1.19", "1.22", "networking.k8s.io/v1"},
+	{"networking.k8s.io/v1beta1", "IngressClass", "1.19", "1.22", "networking.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRole", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRoleBinding", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "Role", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "RoleBinding", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"admissionregistration.k8s.io/v1beta1", "MutatingWebhookConfiguration", "1.16", "1.22", "admissionregistration.k8s.io/v1"},
+	{"admissionregistration.k8s.io/v1beta1", "ValidatingWebhookConfiguration", "1.16", "1.22", "admissionregistration.k8s.io/v1"},
+	{"apiextensions.k8s.io/v1beta1", "CustomResourceDefinition", "1.16", "1.22", "apiextensions.k8s.io/v1"},
+	{"apiregistration.k8s.io/v1beta1", "APIService", "1.19", "1.22", "apiregistration.k8s.io/v1"},
+	{"certificates.k8s.io/v1beta1", "CertificateSigningRequest", "1.19", "1.22", "certificates.k8s.io/v1"},
+	{"coordination.k8s.io/v1beta1", "Lease", "1.19", "1.22", "coordination.k8s.io/v1"},
+	{"scheduling.k8s.io/v1beta1", "PriorityClass", "1.14", "1.22", "scheduling.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "CSIDriver", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "CSINode", "1.17", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "StorageClass", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "VolumeAttachment", "1.19", "1.22", "storage.k8s.io/v1"},
+
+	// Removed in 1.25
+	{"policy/v1beta1", "PodSecurityPolicy", "1.21", "1.25", "Pod Security Admission"},
+	{"policy/v1beta1", "PodDisruptionBudget", "1.21", "1.25", "policy/v1"},
+	{"batch/v1beta1", "CronJob", "1.21", "1.25", "batch/v1"},
+	{"discovery.k8s.io/v1beta1", "EndpointSlice", "1.21", "1.25", "discovery.k8s.io/v1"},
+	{"events.k8s.io/v1beta1", "Event", "1.19", "1.25", "events.k8s.io/v1"},
+	{"autoscaling/v2beta1", "HorizontalPodAutoscaler", "1.22", "1.25", "autoscaling/v2"},
+	{"node.k8s.io/v1beta1", "RuntimeClass", "1.20", "1.25", "node.k8s.io/v1"},
+
+	// Removed in 1.26 and later
+	{"autoscaling/v2beta2", "HorizontalPodAutoscaler", "1.23", "1.26", "autoscaling/v2"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "FlowSchema", "1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "PriorityLevelConfiguration", "1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "CSIStorageCapacity", "1.24", "1.27", "storage.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta2", "FlowSchema", "1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta2", "PriorityLevelConfiguration", "1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta3", "FlowSchema", "1.29", "1.32", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta3", "PriorityLevelConfiguration", "1.29", "1.32", "flowcontrol.apiserver.k8s.io/v1"},
+}
+
+// DeprecatedAPI is a resource using a deprecated or removed API version
+type DeprecatedAPI struct {
+	Kind         string
+	Name         string
+	Namespace    string
+	APIVersion   string
+	Source       string // "object" or the last-applied-configuration annotation
+	DeprecatedIn string
+	RemovedIn    string
+	Replacement  string
+	Removed      bool // removed in or before the target version
+}
+
+// ParseKubernetesVersion parses a version such as "1.25", "v1.25" or "1.25.3" into
+// its minor version number
+func ParseKubernetesVersion(version string) (int, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".")
+	if len(parts) < 2 || parts[0] != "1" {
+		return 0, fmt.Errorf("invalid Kubernetes version %q, expected 1.xx", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid Kubernetes version %q, expected 1.xx", version)
+	}
+	return minor, nil
+}
+
+// GetDeprecatedAPIs finds resources whose apiVersion, or the apiVersion in their
+// last-applied-configuration annotation, is deprecated by the TargetVersion option.
+// Resources using an API removed in or before it are marked Removed and sorted first.
+func GetDeprecatedAPIs(config *ClusterConfig, opts Options) []DeprecatedAPI {
+	target := -1
+	if opts.TargetVersion != "" {
+		if minor, err := ParseKubernetesVersion(opts.TargetVersion); err == nil {
+			target = minor
+		}
+	}
+
+	deprecations := make(map[string]APIDeprecation)
+	for _, d := range APIDeprecations {
+		deprecations[d.APIVersion+"/"+d.Kind] = d
+	}
+
+	var results []DeprecatedAPI
+	check := func(item Item, apiVersion, kind, source string) {
+		d, ok := deprecations[apiVersion+"/"+kind]
+		if !ok {
+			return
+		}
+		deprecatedIn, _ := ParseKubernetesVersion(d.DeprecatedIn)
+		removedIn, _ := ParseKubernetesVersion(d.RemovedIn)
+		if target >= 0 && target < deprecatedIn {
+			return // still fully supported in the target version
+		}
+		results = append(results, DeprecatedAPI{
+			Kind:         kind,
+			Name:         item.Metadata.Name,
+			Namespace:    item.Metadata.Namespace,
+			APIVersion:   apiVersion,
+			Source:       source,
+			DeprecatedIn: d.DeprecatedIn,
+			RemovedIn:    d.RemovedIn,
+			Replacement:  d.Replacement,
+			Removed:      target >= removedIn,
+		})
+	}
+
+	for _, item := range config.Items {
+		check(item, item.ApiVersion, item.Kind, "object")
+
+		// The API server returns objects at its preferred version, so the version in
+		// the applied manifest is what breaks the next kubectl apply
+		applied, ok := item.Metadata.Annotations[lastAppliedAnnotation]
+		if !ok {
+			continue
+		}
+		var manifest struct {
+			ApiVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}
+		if err := json.Unmarshal([]byte(applied), &manifest); err != nil || manifest.ApiVersion == item.ApiVersion {
+			continue
+		}
+		kind := manifest.Kind
+		if kind == "" {
+			kind = item.Kind
+		}
+		check(item, manifest.ApiVersion, kind, lastAppliedAnnotation)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Removed != b.Removed {
+			return a.Removed
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return results
+}
+
+// deprecatedAPIFindings adapts GetDeprecatedAPIs to findings. APIs removed by the
+// target version are high severity.
+func deprecatedAPIFindings(config *ClusterConfig, opts Options) []Finding {
+	var findings []Finding
+	for _, d := range GetDeprecatedAPIs(config, opts) {
+		finding := Finding{
+			Namespace: d.Namespace,
+			Kind:      d.Kind,
+			Name:      d.Name,
+			Rule:      d.Source + " " + d.APIVersion,
+		}
+		status := fmt.Sprintf("deprecated in %s, removed in %s", d.DeprecatedIn, d.RemovedIn)
+		if d.Removed {
+			finding.Severity = SeverityHigh
+			status = fmt.Sprintf("removed in %s", d.RemovedIn)
+		}
+		finding.Details = fmt.Sprintf("%s %s, use %s", d.APIVersion, status, d.Replacement)
+		if d.Source != "object" {
+			finding.Details += " (from last-applied-configuration)"
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
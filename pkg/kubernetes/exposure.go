@@ -0,0 +1,389 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ServiceSpec is the subset of a Service spec used for exposure analysis
+type ServiceSpec struct {
+	Type        string            `json:"type,omitempty"`
+	Selector    map[string]string `json:"selector,omitempty"`
+	Ports       []ServicePort     `json:"ports,omitempty"`
+	ExternalIPs []string          `json:"externalIPs,omitempty"`
+}
+
+// ServicePort is a port exposed by a Service
+type ServicePort struct {
+	Name     string `json:"name,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+	Port     int    `json:"port,omitempty"`
+	NodePort int    `json:"nodePort,omitempty"`
+}
+
+// serviceStatus holds the addresses assigned to a LoadBalancer Service
+type serviceStatus struct {
+	LoadBalancer struct {
+		Ingress []struct {
+			IP       string `json:"ip,omitempty"`
+			Hostname string `json:"hostname,omitempty"`
+		} `json:"ingress,omitempty"`
+	} `json:"loadBalancer"`
+}
+
+// ingressSpec is the subset of an Ingress spec used for exposure analysis. Both the
+// networking.k8s.io/v1 and the older extensions/v1beta1 backend formats are read.
+type ingressSpec struct {
+	DefaultBackend *ingressBackend `json:"defaultBackend,omitempty"`
+	Backend        *ingressBackend `json:"backend,omitempty"`
+	TLS            []struct {
+		Hosts []string `json:"hosts,omitempty"`
+	} `json:"tls,omitempty"`
+	Rules []struct {
+		Host string `json:"host,omitempty"`
+		HTTP *struct {
+			Paths []struct {
+				Backend ingressBackend `json:"backend"`
+			} `json:"paths,omitempty"`
+		} `json:"http,omitempty"`
+	} `json:"rules,omitempty"`
+}
+
+// ingressBackend is the Service an Ingress path routes to
+type ingressBackend struct {
+	Service *struct {
+		Name string `json:"name"`
+	} `json:"service,omitempty"`
+	ServiceName string `json:"serviceName,omitempty"`
+}
+
+// serviceName returns the backend Service name in either Ingress format
+func (b ingressBackend) serviceName() string {
+	if b.Service != nil {
+		return b.Service.Name
+	}
+	return b.ServiceName
+}
+
+// httpRouteSpec is the subset of a Gateway API HTTPRoute spec used for exposure analysis
+type httpRouteSpec struct {
+	Hostnames  []string `json:"hostnames,omitempty"`
+	ParentRefs []struct {
+		Name        string `json:"name"`
+		Namespace   string `json:"namespace,omitempty"`
+		SectionName string `json:"sectionName,omitempty"`
+	} `json:"parentRefs,omitempty"`
+	Rules []struct {
+		BackendRefs []struct {
+			Kind      string `json:"kind,omitempty"`
+			Name      string `json:"name"`
+			Namespace string `json:"namespace,omitempty"`
+		} `json:"backendRefs,omitempty"`
+	} `json:"rules,omitempty"`
+}
+
+// gatewaySpec is the subset of a Gateway API Gateway spec used to find TLS listeners
+type gatewaySpec struct {
+	Listeners []struct {
+		Name     string `json:"name"`
+		Protocol string `json:"protocol"`
+	} `json:"listeners,omitempty"`
+}
+
+// ExposedWorkload is a workload backing an exposed entry point, with the names of
+// the security checks that reported it
+type ExposedWorkload struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Findings  []string
+}
+
+// ExposureEntry is a way into the cluster from outside: a NodePort, LoadBalancer or
+// externalIPs Service, an Ingress or an HTTPRoute
+type ExposureEntry struct {
+	Kind      string // Service, Ingress or HTTPRoute
+	Name      string
+	Namespace string
+	Type      string   // NodePort, LoadBalancer, ExternalIPs, Ingress or HTTPRoute
+	Hosts     []string // hostnames, external IPs and load balancer addresses
+	Ports     []string
+	TLS       bool     // Ingress and HTTPRoute only
+	Services  []string // backend Services of an Ingress or HTTPRoute
+	Workloads []ExposedWorkload
+}
+
+// IsRisky reports whether any backing workload has security findings
+func (e ExposureEntry) IsRisky() bool {
+	for _, w := range e.Workloads {
+		if len(w.Findings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// exposureRiskChecks are the checks cross-referenced with exposed workloads
+var exposureRiskChecks = []string{CheckPrivileged, CheckCapabilities, CheckHostNamespaces, CheckHostPath}
+
+// GetExposedServices lists everything reachable from outside the cluster, resolved
+// through selectors to the backing workloads. Entries whose workloads also have
+// privileged, capability, host namespace or hostPath findings are listed first.
+func GetExposedServices(config *ClusterConfig, opts Options) []ExposureEntry {
+	// Security findings of each workload, by namespace/kind/name
+	risks := make(map[string][]string)
+	for _, id := range exposureRiskChecks {
+		analyzer, ok := GetAnalyzer(id)
+		if !ok {
+			continue
+		}
+		for _, f := range RunAnalyzer(analyzer, config, opts) {
+			key := fmt.Sprintf("%s/%s/%s", namespaceOrDefault(f.Namespace), f.Kind, f.Name)
+			if !containsString(risks[key], analyzer.Name()) {
+				risks[key] = append(risks[key], analyzer.Name())
+			}
+		}
+	}
+
+	var templates []PodTemplate
+	for _, template := range GetPodTemplates(config) {
+		if !template.IsManaged() {
+			templates = append(templates, template)
+		}
+	}
+
+	services := make(map[string]ServiceSpec)
+	for _, item := range config.Items {
+		if item.Kind == "Service" {
+			var spec ServiceSpec
+			decodeInto(item.Spec, &spec)
+			services[namespaceOrDefault(item.Metadata.Namespace)+"/"+item.Metadata.Name] = spec
+		}
+	}
+
+	// workloadsFor resolves Services to the workloads their selectors match
+	workloadsFor := func(namespace string, serviceNames []string) []ExposedWorkload {
+		var workloads []ExposedWorkload
+		seen := make(map[string]bool)
+		for _, name := range serviceNames {
+			spec, ok := services[namespace+"/"+name]
+			if !ok || len(spec.Selector) == 0 {
+				continue
+			}
+			for _, template := range templates {
+				if namespaceOrDefault(template.Namespace) != namespace || !MatchesSelector(spec.Selector, template.Labels) {
+					continue
+				}
+				key := fmt.Sprintf("%s/%s/%s", namespace, template.Kind, template.Name)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				workloads = append(workloads, ExposedWorkload{
+					Kind:      template.Kind,
+					Name:      template.Name,
+					Namespace: namespace,
+					Findings:  risks[key],
+				})
+			}
+		}
+		return workloads
+	}
+
+	gateways := make(map[string]gatewaySpec)
+	for _, item := range config.Items {
+		if item.Kind == "Gateway" {
+			var spec gatewaySpec
+			decodeInto(item.Spec, &spec)
+			gateways[namespaceOrDefault(item.Metadata.Namespace)+"/"+item.Metadata.Name] = spec
+		}
+	}
+
+	var entries []ExposureEntry
+	for _, item := range config.Items {
+		namespace := namespaceOrDefault(item.Metadata.Namespace)
+		entry := ExposureEntry{Kind: item.Kind, Name: item.Metadata.Name, Namespace: namespace}
+
+		switch item.Kind {
+		case "Service":
+			spec := services[namespace+"/"+item.Metadata.Name]
+			switch {
+			case spec.Type == "LoadBalancer":
+				entry.Type = "LoadBalancer"
+			case spec.Type == "NodePort":
+				entry.Type = "NodePort"
+			case len(spec.ExternalIPs) > 0:
+				entry.Type = "ExternalIPs"
+			default:
+				continue
+			}
+
+			entry.Hosts = append(entry.Hosts, spec.ExternalIPs...)
+			var status serviceStatus
+			if item.Status != nil && decodeInto(item.Status, &status) == nil {
+				for _, lb := range status.LoadBalancer.Ingress {
+					if lb.IP != "" {
+						entry.Hosts = append(entry.Hosts, lb.IP)
+					}
+					if lb.Hostname != "" {
+						entry.Hosts = append(entry.Hosts, lb.Hostname)
+					}
+				}
+			}
+			for _, port := range spec.Ports {
+				protocol := port.Protocol
+				if protocol == "" {
+					protocol = "TCP"
+				}
+				text := fmt.Sprintf("%d/%s", port.Port, protocol)
+				if port.NodePort != 0 && entry.Type != "ExternalIPs" {
+					text += fmt.Sprintf(" (node %d)", port.NodePort)
+				}
+				entry.Ports = append(entry.Ports, text)
+			}
+			entry.Workloads = workloadsFor(namespace, []string{item.Metadata.Name})
+
+		case "Ingress":
+			var spec ingressSpec
+			if decodeInto(item.Spec, &spec) != nil {
+				continue
+			}
+			entry.Type = "Ingress"
+			entry.TLS = len(spec.TLS) > 0
+			for _, backend := range []*ingressBackend{spec.DefaultBackend, spec.Backend} {
+				if backend != nil && backend.serviceName() != "" {
+					entry.Services = appendUnique(entry.Services, backend.serviceName())
+				}
+			}
+			for _, rule := range spec.Rules {
+				host := rule.Host
+				if host == "" {
+					host = "*"
+				}
+				entry.Hosts = appendUnique(entry.Hosts, host)
+				if rule.HTTP == nil {
+					continue
+				}
+				for _, path := range rule.HTTP.Paths {
+					if name := path.Backend.serviceName(); name != "" {
+						entry.Services = appendUnique(entry.Services, name)
+					}
+				}
+			}
+			entry.Workloads = workloadsFor(namespace, entry.Services)
+
+		case "HTTPRoute":
+			var spec httpRouteSpec
+			if decodeInto(item.Spec, &spec) != nil {
+				continue
+			}
+			entry.Type = "HTTPRoute"
+			entry.Hosts = spec.Hostnames
+			if len(entry.Hosts) == 0 {
+				entry.Hosts = []string{"*"}
+			}
+			for _, parent := range spec.ParentRefs {
+				parentNamespace := parent.Namespace
+				if parentNamespace == "" {
+					parentNamespace = namespace
+				}
+				for _, listener := range gateways[parentNamespace+"/"+parent.Name].Listeners {
+					if (parent.SectionName == "" || parent.SectionName == listener.Name) &&
+						(listener.Protocol == "HTTPS" || listener.Protocol == "TLS") {
+						entry.TLS = true
+					}
+				}
+			}
+			var workloads []ExposedWorkload
+			for _, rule := range spec.Rules {
+				for _, ref := range rule.BackendRefs {
+					if ref.Kind != "" && ref.Kind != "Service" {
+						continue
+					}
+					refNamespace := ref.Namespace
+					if refNamespace == "" {
+						refNamespace = namespace
+					}
+					service := ref.Name
+					if refNamespace != namespace {
+						service = refNamespace + "/" + ref.Name
+					}
+					if !containsString(entry.Services, service) {
+						entry.Services = append(entry.Services, service)
+						workloads = append(workloads, workloadsFor(refNamespace, []string{ref.Name})...)
+					}
+				}
+			}
+			entry.Workloads = workloads
+
+		default:
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.IsRisky() != b.IsRisky() {
+			return a.IsRisky()
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	return entries
+}
+
+// appendUnique appends a value to a slice if it is not already present
+func appendUnique(values []string, value string) []string {
+	if containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+// exposureFindings adapts GetExposedServices to findings. Entry points backed by
+// workloads with other security findings are reported as high severity.
+func exposureFindings(config *ClusterConfig, opts Options) []Finding {
+	var findings []Finding
+	for _, entry := range GetExposedServices(config, opts) {
+		details := entry.Type
+		if len(entry.Hosts) > 0 {
+			details += " " + strings.Join(entry.Hosts, ", ")
+		}
+		if len(entry.Ports) > 0 {
+			details += " ports " + strings.Join(entry.Ports, ", ")
+		}
+		if (entry.Type == "Ingress" || entry.Type == "HTTPRoute") && !entry.TLS {
+			details += " without TLS"
+		}
+
+		severity := SeverityLow
+		var risky []string
+		for _, w := range entry.Workloads {
+			if len(w.Findings) > 0 {
+				risky = append(risky, fmt.Sprintf("%s %s (%s)", w.Kind, w.Name, strings.Join(w.Findings, ", ")))
+			}
+		}
+		if len(risky) > 0 {
+			severity = SeverityHigh
+			details += "; exposes " + strings.Join(risky, "; ")
+		}
+
+		findings = append(findings, Finding{
+			Severity:  severity,
+			Namespace: entry.Namespace,
+			Kind:      entry.Kind,
+			Name:      entry.Name,
+			Details:   details,
+		})
+	}
+	return findings
+}
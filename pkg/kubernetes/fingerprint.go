@@ -0,0 +1,303 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Distributions recognised by FingerprintCluster
+const (
+	DistributionKind      = "kind"
+	DistributionEKS       = "EKS"
+	DistributionGKE       = "GKE"
+	DistributionAKS       = "AKS"
+	DistributionOpenShift = "OpenShift"
+	DistributionK3s       = "k3s"
+	DistributionRKE2      = "RKE2"
+	DistributionUnknown   = "unknown"
+)
+
+// NodeFingerprint is the software a node runs, from its status.nodeInfo
+type NodeFingerprint struct {
+	Name             string `json:"name"`
+	KubeletVersion   string `json:"kubelet_version,omitempty"`
+	ContainerRuntime string `json:"container_runtime,omitempty"`
+	OSImage          string `json:"os_image,omitempty"`
+	KernelVersion    string `json:"kernel_version,omitempty"`
+	Architecture     string `json:"architecture,omitempty"`
+}
+
+// ClusterFingerprint identifies the Kubernetes version and distribution a snapshot came from
+type ClusterFingerprint struct {
+	KubernetesVersion string            `json:"kubernetes_version,omitempty"` // newest kubelet version
+	Distribution      string            `json:"distribution"`
+	Evidence          []string          `json:"evidence,omitempty"` // why the distribution was chosen
+	Nodes             []NodeFingerprint `json:"nodes,omitempty"`
+}
+
+// String summarises the fingerprint, e.g. "EKS v1.29.3-eks-ae9a62a (3 nodes)"
+func (f ClusterFingerprint) String() string {
+	parts := []string{f.Distribution}
+	if f.KubernetesVersion != "" {
+		parts = append(parts, f.KubernetesVersion)
+	}
+	switch len(f.Nodes) {
+	case 0:
+	case 1:
+		parts = append(parts, "(1 node)")
+	default:
+		parts = append(parts, fmt.Sprintf("(%d nodes)", len(f.Nodes)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// NodeSoftware is a combination of node software and the number of nodes running it
+type NodeSoftware struct {
+	Count            int
+	KubeletVersion   string
+	ContainerRuntime string
+	OSImage          string
+	KernelVersion    string
+}
+
+// NodeSoftware groups the nodes by the software they run, most common first
+func (f ClusterFingerprint) NodeSoftware() []NodeSoftware {
+	var groups []NodeSoftware
+	index := make(map[NodeSoftware]int) // software, with Count zero, to its group
+	for _, node := range f.Nodes {
+		software := NodeSoftware{
+			KubeletVersion:   node.KubeletVersion,
+			ContainerRuntime: node.ContainerRuntime,
+			OSImage:          node.OSImage,
+			KernelVersion:    node.KernelVersion,
+		}
+		i, ok := index[software]
+		if !ok {
+			i = len(groups)
+			index[software] = i
+			groups = append(groups, software)
+		}
+		groups[i].Count++
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Count > groups[j].Count
+	})
+	return groups
+}
+
+// nodeInfo is the part of a Node's status describing its software
+type nodeInfo struct {
+	NodeInfo struct {
+		KubeletVersion          string `json:"kubeletVersion,omitempty"`
+		ContainerRuntimeVersion string `json:"containerRuntimeVersion,omitempty"`
+		OSImage                 string `json:"osImage,omitempty"`
+		KernelVersion           string `json:"kernelVersion,omitempty"`
+		Architecture            string `json:"architecture,omitempty"`
+	} `json:"nodeInfo,omitempty"`
+}
+
+// distributionSignals are the traces each distribution leaves, in the order they are
+// checked. Distributions built on another (RKE2 and k3s on containerd, OpenShift on
+// cloud providers) come first so the more specific one wins.
+var distributionSignals = []struct {
+	distribution string
+	match        func(s clusterSignals) string
+}{
+	{DistributionOpenShift, func(s clusterSignals) string {
+		return s.first(s.namespacePrefix("openshift-"), s.crdSuffix(".openshift.io"), s.osImage("Red Hat Enterprise Linux CoreOS"))
+	}},
+	{DistributionRKE2, func(s clusterSignals) string {
+		return s.first(s.kubelet("+rke2"), s.label("node.kubernetes.io/instance-type", "rke2"))
+	}},
+	{DistributionK3s, func(s clusterSignals) string {
+		return s.first(s.kubelet("+k3s"), s.label("node.kubernetes.io/instance-type", "k3s"))
+	}},
+	{DistributionEKS, func(s clusterSignals) string {
+		return s.first(s.kubelet("-eks-"), s.label("eks.amazonaws.com/nodegroup", ""), s.providerID("aws://"))
+	}},
+	{DistributionGKE, func(s clusterSignals) string {
+		return s.first(s.kubelet("-gke."), s.label("cloud.google.com/gke-nodepool", ""), s.providerID("gce://"))
+	}},
+	{DistributionAKS, func(s clusterSignals) string {
+		return s.first(s.label("kubernetes.azure.com/cluster", ""), s.label("kubernetes.azure.com/agentpool", ""), s.providerID("azure://"))
+	}},
+	{DistributionKind, func(s clusterSignals) string {
+		return s.first(s.providerID("kind://"), s.namespace("local-path-storage"))
+	}},
+}
+
+// clusterSignals holds what a snapshot shows about the cluster it came from
+type clusterSignals struct {
+	namespaces  []string
+	crds        []string
+	nodes       []NodeFingerprint
+	labels      []map[string]string // node labels
+	providerIDs []string
+}
+
+// first returns the first non-empty piece of evidence
+func (s clusterSignals) first(evidence ...string) string {
+	for _, e := range evidence {
+		if e != "" {
+			return e
+		}
+	}
+	return ""
+}
+
+func (s clusterSignals) namespace(name string) string {
+	if containsString(s.namespaces, name) {
+		return "namespace " + name
+	}
+	return ""
+}
+
+func (s clusterSignals) namespacePrefix(prefix string) string {
+	for _, namespace := range s.namespaces {
+		if strings.HasPrefix(namespace, prefix) {
+			return "namespace " + namespace
+		}
+	}
+	return ""
+}
+
+func (s clusterSignals) crdSuffix(suffix string) string {
+	for _, crd := range s.crds {
+		if strings.HasSuffix(crd, suffix) {
+			return "CRD " + crd
+		}
+	}
+	return ""
+}
+
+func (s clusterSignals) kubelet(marker string) string {
+	for _, node := range s.nodes {
+		if strings.Contains(node.KubeletVersion, marker) {
+			return "kubelet " + node.KubeletVersion
+		}
+	}
+	return ""
+}
+
+func (s clusterSignals) osImage(prefix string) string {
+	for _, node := range s.nodes {
+		if strings.HasPrefix(node.OSImage, prefix) {
+			return "node OS " + node.OSImage
+		}
+	}
+	return ""
+}
+
+// label matches a node label by key, and by value when value is not empty
+func (s clusterSignals) label(key, value string) string {
+	for _, labels := range s.labels {
+		if v, ok := labels[key]; ok && (value == "" || v == value) {
+			return "node label " + key
+		}
+	}
+	return ""
+}
+
+func (s clusterSignals) providerID(prefix string) string {
+	for _, id := range s.providerIDs {
+		if strings.HasPrefix(id, prefix) {
+			return "providerID " + prefix
+		}
+	}
+	return ""
+}
+
+// FingerprintCluster identifies the distribution of the cluster a configuration came
+// from using Node status.nodeInfo, providerIDs and labels and characteristic namespaces
+// and CRDs, and records each node's kubelet, container runtime, OS and kernel.
+// The Kubernetes version is the newest kubelet's, as kubelets may lag the control plane.
+func FingerprintCluster(config *ClusterConfig) ClusterFingerprint {
+	var signals clusterSignals
+	for _, item := range config.Items {
+		switch item.Kind {
+		case "Namespace":
+			signals.namespaces = append(signals.namespaces, item.Metadata.Name)
+		case "CustomResourceDefinition":
+			signals.crds = append(signals.crds, item.Metadata.Name)
+		case "Node":
+			var status nodeInfo
+			decodeInto(item.Status, &status)
+			var spec struct {
+				ProviderID string `json:"providerID,omitempty"`
+			}
+			decodeInto(item.Spec, &spec)
+
+			signals.nodes = append(signals.nodes, NodeFingerprint{
+				Name:             item.Metadata.Name,
+				KubeletVersion:   status.NodeInfo.KubeletVersion,
+				ContainerRuntime: status.NodeInfo.ContainerRuntimeVersion,
+				OSImage:          status.NodeInfo.OSImage,
+				KernelVersion:    status.NodeInfo.KernelVersion,
+				Architecture:     status.NodeInfo.Architecture,
+			})
+			signals.labels = append(signals.labels, item.Metadata.Labels)
+			signals.providerIDs = append(signals.providerIDs, spec.ProviderID)
+		}
+	}
+
+	fingerprint := ClusterFingerprint{
+		Distribution: DistributionUnknown,
+		Nodes:        signals.nodes,
+	}
+	for _, signal := range distributionSignals {
+		if evidence := signal.match(signals); evidence != "" {
+			fingerprint.Distribution = signal.distribution
+			fingerprint.Evidence = append(fingerprint.Evidence, evidence)
+			break
+		}
+	}
+	for _, node := range signals.nodes {
+		if compareKubeletVersions(node.KubeletVersion, fingerprint.KubernetesVersion) > 0 {
+			fingerprint.KubernetesVersion = node.KubeletVersion
+		}
+	}
+
+	sort.Slice(fingerprint.Nodes, func(i, j int) bool {
+		return fingerprint.Nodes[i].Name < fingerprint.Nodes[j].Name
+	})
+	return fingerprint
+}
+
+// compareKubeletVersions compares the major, minor and patch numbers of two versions
+// such as "v1.29.3-eks-ae9a62a", ignoring any suffix. An empty version is the oldest.
+func compareKubeletVersions(a, b string) int {
+	parse := func(version string) []int {
+		version = strings.TrimPrefix(version, "v")
+		if i := strings.IndexAny(version, "-+"); i >= 0 {
+			version = version[:i]
+		}
+		var numbers []int
+		for _, part := range strings.Split(version, ".") {
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				break
+			}
+			numbers = append(numbers, n)
+		}
+		return numbers
+	}
+	x, y := parse(a), parse(b)
+	for i := 0; i < len(x) || i < len(y); i++ {
+		var p, q int
+		if i < len(x) {
+			p = x[i]
+		}
+		if i < len(y) {
+			q = y[i]
+		}
+		if p != q {
+			if p < q {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
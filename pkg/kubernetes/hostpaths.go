@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// HostPathRule classifies a sensitive host path and the paths beneath it
+type HostPathRule struct {
+	Path     string   `json:"path"`
+	Severity Severity `json:"severity"`
+	Reason   string   `json:"reason"`
+}
+
+// DefaultHostPathCatalogue is the built-in catalogue of sensitive host paths
+var DefaultHostPathCatalogue = []HostPathRule{
+	{"/", SeverityCritical, "host root filesystem = full node compromise"},
+	{"/var/run/docker.sock", SeverityCritical, "container runtime socket = node takeover"},
+	{"/run/docker.sock", SeverityCritical, "container runtime socket = node takeover"},
+	{"/var/run/containerd/containerd.sock", SeverityCritical, "container runtime socket = node takeover"},
+	{"/run/containerd/containerd.sock", SeverityCritical, "container runtime socket = node takeover"},
+	{"/var/run/crio/crio.sock", SeverityCritical, "container runtime socket = node takeover"},
+	{"/run/crio/crio.sock", SeverityCritical, "container runtime socket = node takeover"},
+	{"/var/run/cri-dockerd.sock", SeverityCritical, "container runtime socket = node takeover"},
+	{"/var/lib/kubelet", SeverityCritical, "kubelet credentials and the volumes and service account tokens of every pod on the node"},
+	{"/etc/kubernetes", SeverityCritical, "kubeconfigs and PKI of the kubelet and control plane"},
+	{"/var/lib/etcd", SeverityCritical, "etcd data = every Secret in the cluster"},
+	{"/etc", SeverityHigh, "host configuration; writable allows changing users, sudoers and cron jobs"},
+	{"/root", SeverityHigh, "root's home directory and SSH keys"},
+	{"/proc", SeverityHigh, "host processes and kernel parameters"},
+	{"/sys", SeverityHigh, "kernel and device settings, including cgroups"},
+	{"/dev", SeverityHigh, "host devices, including disks"},
+	{"/boot", SeverityHigh, "kernel and bootloader"},
+	{"/usr", SeverityHigh, "host binaries; writable allows replacing them"},
+	{"/bin", SeverityHigh, "host binaries; writable allows replacing them"},
+	{"/sbin", SeverityHigh, "host binaries; writable allows replacing them"},
+	{"/lib", SeverityHigh, "host libraries; writable allows replacing them"},
+	{"/var/lib/docker", SeverityHigh, "images and filesystems of every container on the node"},
+	{"/var/lib/containerd", SeverityHigh, "images and filesystems of every container on the node"},
+	{"/etc/cni", SeverityHigh, "CNI configuration; writable allows hijacking pod networking"},
+	{"/opt/cni/bin", SeverityHigh, "CNI plugin binaries run as root by the kubelet"},
+	{"/home", SeverityMedium, "user home directories and SSH keys"},
+	{"/var/log", SeverityMedium, "node and pod logs; writable allows reading host files through log symlinks"},
+	{"/tmp", SeverityLow, "temporary files shared with host processes"},
+}
+
+// unlistedHostPath is the classification of paths outside the catalogue
+var unlistedHostPath = HostPathRule{Severity: SeverityLow, Reason: "not a known sensitive path"}
+
+// LoadHostPathCatalogue reads a YAML or JSON list of rules and merges it into the
+// built-in catalogue: a rule for a path already listed replaces it, others are added.
+// A severity of "info" effectively silences a path.
+func LoadHostPathCatalogue(file string) ([]HostPathRule, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read host path catalogue: %w", err)
+	}
+	var rules []HostPathRule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse host path catalogue: %w", err)
+	}
+
+	catalogue := append([]HostPathRule(nil), DefaultHostPathCatalogue...)
+	for _, rule := range rules {
+		if rule.Path == "" || !strings.HasPrefix(rule.Path, "/") {
+			return nil, fmt.Errorf("invalid host path %q in catalogue: must be absolute", rule.Path)
+		}
+		switch rule.Severity {
+		case SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo:
+		default:
+			return nil, fmt.Errorf("invalid severity %q for %s in catalogue", rule.Severity, rule.Path)
+		}
+		rule.Path = path.Clean(rule.Path)
+
+		replaced := false
+		for i := range catalogue {
+			if catalogue[i].Path == rule.Path {
+				catalogue[i], replaced = rule, true
+			}
+		}
+		if !replaced {
+			catalogue = append(catalogue, rule)
+		}
+	}
+	return catalogue, nil
+}
+
+// ClassifyHostPath returns the severity of mounting a host path and why, using a
+// catalogue or DefaultHostPathCatalogue when it is nil. A rule covers its path and
+// everything beneath it, the most specific rule winning; the root rule covers only /
+// itself. Mounting a directory that contains a more dangerous path, such as /var/run
+// containing the Docker socket, takes that path's severity.
+func ClassifyHostPath(catalogue []HostPathRule, hostPath string) (Severity, string) {
+	if catalogue == nil {
+		catalogue = DefaultHostPathCatalogue
+	}
+	hostPath = path.Clean(hostPath)
+
+	match := unlistedHostPath
+	matched := ""
+	for _, rule := range catalogue {
+		covers := rule.Path == hostPath ||
+			(rule.Path != "/" && strings.HasPrefix(hostPath, rule.Path+"/"))
+		if covers && len(rule.Path) > len(matched) {
+			match, matched = rule, rule.Path
+		}
+	}
+
+	for _, rule := range catalogue {
+		beneath := rule.Path != hostPath &&
+			(hostPath == "/" || strings.HasPrefix(rule.Path, hostPath+"/"))
+		if beneath && rule.Severity.Rank() > match.Severity.Rank() {
+			match = HostPathRule{
+				Severity: rule.Severity,
+				Reason:   fmt.Sprintf("contains %s: %s", rule.Path, rule.Reason),
+			}
+		}
+	}
+	return match.Severity, match.Reason
+}
@@ -0,0 +1,275 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Image hygiene issues
+const (
+	ImageIssueLatest     = "latest-tag"           // :latest or no tag at all
+	ImageIssueUnpinned   = "not-pinned"           // not pinned by digest
+	ImageIssueRegistry   = "registry-not-allowed" // registry outside the allowlist
+	ImageIssueTagDrift   = "tag-drift"            // namespaces run the repository at different tags
+	defaultImageRegistry = "docker.io"
+)
+
+// ImageContainer is a container and the image it runs
+type ImageContainer struct {
+	Name      string
+	Namespace string
+	Kind      string
+	PodName   string
+	Image     string
+}
+
+// ImageInfo is a distinct image reference and every container using it
+type ImageInfo struct {
+	Image      string
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+	Uses       []ImageContainer
+	Issues     []string
+}
+
+// ImageDrift is a repository that namespaces run at different tags or digests
+type ImageDrift struct {
+	Repository        string // registry/repository
+	Versions          []string
+	Namespaces        []string
+	NamespaceVersions map[string][]string // versions run in each namespace
+}
+
+// ImageInventory is every image in a configuration and its hygiene issues
+type ImageInventory struct {
+	Images []ImageInfo
+	Drift  []ImageDrift
+}
+
+// ParseImageReference splits an image reference into registry, repository, tag and
+// digest, applying Docker Hub defaults for short names ("nginx" is docker.io/library/nginx)
+func ParseImageReference(image string) (registry, repository, tag, digest string) {
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		digest = name[i+1:]
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		tag = name[i+1:]
+		name = name[:i]
+	}
+
+	registry = defaultImageRegistry
+	if i := strings.Index(name, "/"); i >= 0 {
+		first := name[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			registry = first
+			name = name[i+1:]
+		}
+	}
+	if registry == defaultImageRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	return registry, name, tag, digest
+}
+
+// registryAllowed reports whether an image's registry/repository matches the allowlist
+func registryAllowed(allowlist []string, registry, repository string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+	full := registry + "/" + repository
+	for _, allowed := range allowlist {
+		allowed = strings.TrimSuffix(allowed, "/")
+		if registry == allowed || strings.HasPrefix(full, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetImageContainers returns the image of every container in the configuration
+func GetImageContainers(config *ClusterConfig) []ImageContainer {
+	var results []ImageContainer
+
+	// Workloads created by a controller, including old ReplicaSets of a Deployment, are
+	// skipped as the controller's template is reported instead
+	for _, template := range GetPodTemplates(config) {
+		if template.IsManaged() {
+			continue
+		}
+
+		for _, container := range template.Spec.PodContainers() {
+			if container.Image == "" {
+				continue
+			}
+			results = append(results, ImageContainer{
+				Name:      container.Name,
+				Namespace: template.Namespace,
+				Kind:      template.Kind,
+				PodName:   template.Name,
+				Image:     container.Image,
+			})
+		}
+	}
+
+	return deduplicateImageContainers(results)
+}
+
+// deduplicateImageContainers removes duplicate entries that refer to the same container
+// It prioritizes higher level resources like Deployments over their child resources
+func deduplicateImageContainers(results []ImageContainer) []ImageContainer {
+	containerMap := make(map[string][]ImageContainer)
+	var keys []string
+
+	for _, result := range results {
+		key := fmt.Sprintf("%s|%s|%s", result.Namespace, result.Name, result.Image)
+		if _, ok := containerMap[key]; !ok {
+			keys = append(keys, key)
+		}
+		containerMap[key] = append(containerMap[key], result)
+	}
+
+	var deduplicated []ImageContainer
+	for _, key := range keys {
+		resources := containerMap[key]
+		highest := resources[0]
+		for _, res := range resources[1:] {
+			if workloadPriority(res.Kind) > workloadPriority(highest.Kind) {
+				highest = res
+			}
+		}
+		deduplicated = append(deduplicated, highest)
+	}
+
+	return deduplicated
+}
+
+// GetImageInventory groups the images in a configuration by reference and flags
+// latest or untagged images, images not pinned by digest, registries outside
+// the AllowedRegistries option and repositories running at different tags
+func GetImageInventory(config *ClusterConfig, opts Options) ImageInventory {
+	images := make(map[string]*ImageInfo)
+	for _, c := range GetImageContainers(config) {
+		info, ok := images[c.Image]
+		if !ok {
+			info = &ImageInfo{Image: c.Image}
+			info.Registry, info.Repository, info.Tag, info.Digest = ParseImageReference(c.Image)
+			images[c.Image] = info
+		}
+		info.Uses = append(info.Uses, c)
+	}
+
+	// Versions of each repository per namespace, to find tag drift
+	versions := make(map[string]map[string][]string)
+	for _, info := range images {
+		repository := info.Registry + "/" + info.Repository
+		version := info.Tag
+		if info.Digest != "" {
+			version = "@" + info.Digest
+		} else if version == "" {
+			version = "latest"
+		}
+		if versions[repository] == nil {
+			versions[repository] = make(map[string][]string)
+		}
+		for _, use := range info.Uses {
+			namespace := namespaceOrDefault(use.Namespace)
+			versions[repository][namespace] = appendUnique(versions[repository][namespace], version)
+		}
+	}
+	drifting := make(map[string]bool)
+	for repository, byNamespace := range versions {
+		drifting[repository] = namespacesDisagree(byNamespace)
+	}
+
+	var inventory ImageInventory
+	for _, info := range images {
+		if info.Digest == "" && (info.Tag == "" || info.Tag == "latest") {
+			info.Issues = append(info.Issues, ImageIssueLatest)
+		}
+		if info.Digest == "" {
+			info.Issues = append(info.Issues, ImageIssueUnpinned)
+		}
+		if !registryAllowed(opts.AllowedRegistries, info.Registry, info.Repository) {
+			info.Issues = append(info.Issues, ImageIssueRegistry)
+		}
+		if drifting[info.Registry+"/"+info.Repository] {
+			info.Issues = append(info.Issues, ImageIssueTagDrift)
+		}
+		inventory.Images = append(inventory.Images, *info)
+	}
+	sort.Slice(inventory.Images, func(i, j int) bool {
+		return inventory.Images[i].Image < inventory.Images[j].Image
+	})
+
+	for repository, byNamespace := range versions {
+		if !drifting[repository] {
+			continue
+		}
+		drift := ImageDrift{Repository: repository, NamespaceVersions: byNamespace}
+		for namespace, namespaceVersions := range byNamespace {
+			sort.Strings(namespaceVersions)
+			drift.Namespaces = append(drift.Namespaces, namespace)
+			for _, version := range namespaceVersions {
+				drift.Versions = appendUnique(drift.Versions, version)
+			}
+		}
+		sort.Strings(drift.Versions)
+		sort.Strings(drift.Namespaces)
+		inventory.Drift = append(inventory.Drift, drift)
+	}
+	sort.Slice(inventory.Drift, func(i, j int) bool {
+		return inventory.Drift[i].Repository < inventory.Drift[j].Repository
+	})
+
+	return inventory
+}
+
+// namespacesDisagree reports whether namespaces run different sets of versions of a
+// repository. Several versions within one namespace, such as a sidecar and an init
+// container at different tags, are not drift on their own.
+func namespacesDisagree(byNamespace map[string][]string) bool {
+	first := ""
+	seen := false
+	for _, namespaceVersions := range byNamespace {
+		sorted := append([]string(nil), namespaceVersions...)
+		sort.Strings(sorted)
+		key := strings.Join(sorted, ",")
+		if !seen {
+			first, seen = key, true
+		} else if key != first {
+			return true
+		}
+	}
+	return false
+}
+
+// imageFindings adapts GetImageInventory to findings, one per container with issues.
+// Latest tags and registries outside the allowlist are medium severity.
+func imageFindings(config *ClusterConfig, opts Options) []Finding {
+	var findings []Finding
+	for _, info := range GetImageInventory(config, opts).Images {
+		if len(info.Issues) == 0 {
+			continue
+		}
+		severity := SeverityLow
+		if containsString(info.Issues, ImageIssueLatest) || containsString(info.Issues, ImageIssueRegistry) {
+			severity = SeverityMedium
+		}
+		for _, use := range info.Uses {
+			findings = append(findings, Finding{
+				Severity:  severity,
+				Namespace: use.Namespace,
+				Kind:      use.Kind,
+				Name:      use.PodName,
+				Container: use.Name,
+				Details:   fmt.Sprintf("%s: %s", info.Image, strings.Join(info.Issues, ", ")),
+			})
+		}
+	}
+	return findings
+}
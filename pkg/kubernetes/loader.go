@@ -0,0 +1,218 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SourceError records a manifest file that could not be parsed during a bulk load
+type SourceError struct {
+	Path string
+	Err  error
+}
+
+// Error implements the error interface
+func (e SourceError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// LoadResult is the outcome of loading many manifest files into one configuration
+type LoadResult struct {
+	Files   int
+	Bytes   int64
+	Items   int
+	Skipped []SourceError
+	handler ItemHandler
+}
+
+// IsManifestFile reports whether a file name has a JSON or YAML extension
+func IsManifestFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// LoadDirectory walks a directory tree and passes the items of every JSON and YAML
+// manifest to fn, merging them into one configuration. Each item records the file it
+// came from, relative to dir. Files that fail to parse are reported in
+// LoadResult.Skipped rather than aborting the load.
+func LoadDirectory(dir string, fn ItemHandler) (*LoadResult, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && IsManifestFile(d.Name()) {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+	sort.Strings(paths)
+
+	result := &LoadResult{handler: fn}
+	for _, p := range paths {
+		file, err := os.Open(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+
+		source, err := filepath.Rel(dir, p)
+		if err != nil {
+			source = p
+		}
+		err = result.add(filepath.ToSlash(source), file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+	}
+
+	return result, nil
+}
+
+// LoadArchive reads a tar, tar.gz/tgz or zip archive and passes the items of every
+// JSON and YAML manifest it contains to fn, recording each item's path in the archive.
+func LoadArchive(archivePath string, fn ItemHandler) (*LoadResult, error) {
+	lower := strings.ToLower(archivePath)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return loadZipArchive(archivePath, fn)
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return loadTarArchive(archivePath, true, fn)
+	case strings.HasSuffix(lower, ".tar"):
+		return loadTarArchive(archivePath, false, fn)
+	default:
+		return nil, fmt.Errorf("unsupported archive type '%s'. Supported types are: .tar, .tar.gz, .tgz, .zip", filepath.Base(archivePath))
+	}
+}
+
+// loadTarArchive reads manifests from an optionally gzip-compressed tar archive
+func loadTarArchive(archivePath string, compressed bool, fn ItemHandler) (*LoadResult, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if compressed {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress archive: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	result := &LoadResult{handler: fn}
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || !IsManifestFile(header.Name) {
+			continue
+		}
+
+		if err := result.add(path.Clean(header.Name), tr); err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", header.Name, err)
+		}
+	}
+
+	return result, nil
+}
+
+// loadZipArchive reads manifests from a zip archive
+func loadZipArchive(archivePath string, fn ItemHandler) (*LoadResult, error) {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer zr.Close()
+
+	result := &LoadResult{handler: fn}
+	for _, entry := range zr.File {
+		if entry.FileInfo().IsDir() || !IsManifestFile(entry.Name) {
+			continue
+		}
+
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s in archive: %w", entry.Name, err)
+		}
+		err = result.add(path.Clean(entry.Name), rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", entry.Name, err)
+		}
+	}
+
+	return result, nil
+}
+
+// add decodes one manifest file as a stream and passes its items to the handler,
+// tagging each with its source. A file that fails to parse is skipped as a whole, so
+// its items are only handed on once the whole file has parsed; an error is returned
+// when the file itself cannot be read or the handler fails.
+func (r *LoadResult) add(source string, reader io.Reader) error {
+	counter := &countingReader{reader: reader}
+	var items []Item
+	_, err := DecodeConfigStream(counter, func(item Item) error {
+		item.Source = source
+		items = append(items, item)
+		return nil
+	})
+	r.Files++
+	r.Bytes += counter.n
+	if counter.err != nil {
+		return counter.err
+	}
+	if err != nil {
+		r.Skipped = append(r.Skipped, SourceError{Path: source, Err: fmt.Errorf("failed to parse Kubernetes configuration: %w", err)})
+		return nil
+	}
+
+	for _, item := range items {
+		if err := r.handler(item); err != nil {
+			return err
+		}
+	}
+	r.Items += len(items)
+	return nil
+}
+
+// countingReader counts the bytes read through it and remembers read errors, so they
+// can be told apart from parse errors
+type countingReader struct {
+	reader io.Reader
+	n      int64
+	err    error
+}
+
+func (c *countingReader) Read(buf []byte) (int, error) {
+	n, err := c.reader.Read(buf)
+	c.n += int64(n)
+	if err != nil && err != io.EOF {
+		c.err = err
+	}
+	return n, err
+}
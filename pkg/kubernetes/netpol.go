@@ -0,0 +1,277 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NetworkPolicySpec is the subset of a NetworkPolicy spec used for coverage analysis
+type NetworkPolicySpec struct {
+	PodSelector LabelSelector       `json:"podSelector"`
+	PolicyTypes []string            `json:"policyTypes,omitempty"`
+	Ingress     []NetworkPolicyRule `json:"ingress,omitempty"`
+	Egress      []NetworkPolicyRule `json:"egress,omitempty"`
+}
+
+// NetworkPolicyRule is an ingress or egress rule. An empty peer list allows all traffic.
+type NetworkPolicyRule struct {
+	From []NetworkPolicyPeer `json:"from,omitempty"`
+	To   []NetworkPolicyPeer `json:"to,omitempty"`
+}
+
+// NetworkPolicyPeer is a source or destination allowed by a rule
+type NetworkPolicyPeer struct {
+	PodSelector       *LabelSelector `json:"podSelector,omitempty"`
+	NamespaceSelector *LabelSelector `json:"namespaceSelector,omitempty"`
+	IPBlock           *IPBlock       `json:"ipBlock,omitempty"`
+}
+
+// IPBlock is a CIDR range allowed by a rule
+type IPBlock struct {
+	CIDR   string   `json:"cidr"`
+	Except []string `json:"except,omitempty"`
+}
+
+// hasPolicyType reports whether the policy applies to Ingress or Egress traffic.
+// Without policyTypes a policy always covers ingress, and egress only if it has egress rules.
+func (s NetworkPolicySpec) hasPolicyType(policyType string) bool {
+	if len(s.PolicyTypes) == 0 {
+		return policyType == "Ingress" || (policyType == "Egress" && len(s.Egress) > 0)
+	}
+	return containsString(s.PolicyTypes, policyType)
+}
+
+// NetworkPolicyNamespace summarises the policies in a namespace
+type NetworkPolicyNamespace struct {
+	Namespace          string
+	Policies           int
+	Workloads          int
+	DefaultDenyIngress bool
+	DefaultDenyEgress  bool
+}
+
+// NetworkPolicyWorkload is a workload and the policies relevant to a result
+type NetworkPolicyWorkload struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Policies  []string
+}
+
+// NetworkPolicyRef identifies a NetworkPolicy
+type NetworkPolicyRef struct {
+	Name      string
+	Namespace string
+}
+
+// NetworkPolicyResult is the NetworkPolicy coverage of a configuration
+type NetworkPolicyResult struct {
+	Namespaces []NetworkPolicyNamespace
+	// UnselectedWorkloads are selected by no policy, so all their traffic is allowed
+	UnselectedWorkloads []NetworkPolicyWorkload
+	// UnusedPolicies have a pod selector that matches no workload
+	UnusedPolicies []NetworkPolicyRef
+	// OpenWorkloads are isolated for ingress but admit traffic from every namespace;
+	// Policies lists the policies with the open rules
+	OpenWorkloads []NetworkPolicyWorkload
+}
+
+// networkPolicy is a decoded NetworkPolicy and the workloads it selects
+type networkPolicy struct {
+	name      string
+	namespace string
+	spec      NetworkPolicySpec
+}
+
+// AnalyzeNetworkPolicies evaluates the NetworkPolicies in a configuration against
+// the pod labels of its workloads
+func AnalyzeNetworkPolicies(config *ClusterConfig) NetworkPolicyResult {
+	namespaces := make(map[string]*NetworkPolicyNamespace)
+	namespaceFor := func(name string) *NetworkPolicyNamespace {
+		ns, ok := namespaces[name]
+		if !ok {
+			ns = &NetworkPolicyNamespace{Namespace: name}
+			namespaces[name] = ns
+		}
+		return ns
+	}
+
+	var policies []networkPolicy
+	for _, item := range config.Items {
+		switch item.Kind {
+		case "Namespace":
+			namespaceFor(item.Metadata.Name)
+		case "NetworkPolicy":
+			var spec NetworkPolicySpec
+			if item.Spec != nil && decodeInto(item.Spec, &spec) != nil {
+				continue
+			}
+			policy := networkPolicy{
+				name:      item.Metadata.Name,
+				namespace: namespaceOrDefault(item.Metadata.Namespace),
+				spec:      spec,
+			}
+			policies = append(policies, policy)
+
+			ns := namespaceFor(policy.namespace)
+			ns.Policies++
+			if spec.PodSelector.IsEmpty() {
+				if spec.hasPolicyType("Ingress") && len(spec.Ingress) == 0 {
+					ns.DefaultDenyIngress = true
+				}
+				if spec.hasPolicyType("Egress") && len(spec.Egress) == 0 {
+					ns.DefaultDenyEgress = true
+				}
+			}
+		}
+	}
+
+	var result NetworkPolicyResult
+	used := make(map[int]bool)
+
+	for _, template := range GetPodTemplates(config) {
+		if template.IsManaged() {
+			continue // covered by the controller that created it
+		}
+
+		namespace := namespaceOrDefault(template.Namespace)
+		namespaceFor(namespace).Workloads++
+
+		var selectedBy, openIn []string
+		isolatedIngress := false
+		for i, policy := range policies {
+			if policy.namespace != namespace || !policy.spec.PodSelector.Matches(template.Labels) {
+				continue
+			}
+			used[i] = true
+			selectedBy = append(selectedBy, policy.name)
+
+			if policy.spec.hasPolicyType("Ingress") {
+				isolatedIngress = true
+				if admitsAllNamespaces(policy.spec.Ingress) {
+					openIn = append(openIn, policy.name)
+				}
+			}
+		}
+
+		workload := NetworkPolicyWorkload{Kind: template.Kind, Name: template.Name, Namespace: namespace}
+		if len(selectedBy) == 0 {
+			result.UnselectedWorkloads = append(result.UnselectedWorkloads, workload)
+		} else if isolatedIngress && len(openIn) > 0 {
+			workload.Policies = openIn
+			result.OpenWorkloads = append(result.OpenWorkloads, workload)
+		}
+	}
+
+	for i, policy := range policies {
+		if !used[i] {
+			result.UnusedPolicies = append(result.UnusedPolicies, NetworkPolicyRef{Name: policy.name, Namespace: policy.namespace})
+		}
+	}
+
+	for _, ns := range namespaces {
+		result.Namespaces = append(result.Namespaces, *ns)
+	}
+	sort.Slice(result.Namespaces, func(i, j int) bool {
+		return result.Namespaces[i].Namespace < result.Namespaces[j].Namespace
+	})
+	sortNetworkPolicyWorkloads(result.UnselectedWorkloads)
+	sortNetworkPolicyWorkloads(result.OpenWorkloads)
+	sort.Slice(result.UnusedPolicies, func(i, j int) bool {
+		a, b := result.UnusedPolicies[i], result.UnusedPolicies[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return result
+}
+
+// admitsAllNamespaces reports whether any ingress rule allows traffic from pods in
+// every namespace: a rule without peers, or a peer with an empty namespace selector
+// and no pod restriction
+func admitsAllNamespaces(rules []NetworkPolicyRule) bool {
+	for _, rule := range rules {
+		if len(rule.From) == 0 {
+			return true
+		}
+		for _, peer := range rule.From {
+			if peer.NamespaceSelector != nil && peer.NamespaceSelector.IsEmpty() &&
+				(peer.PodSelector == nil || peer.PodSelector.IsEmpty()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sortNetworkPolicyWorkloads orders workloads by namespace, kind and name
+func sortNetworkPolicyWorkloads(workloads []NetworkPolicyWorkload) {
+	sort.Slice(workloads, func(i, j int) bool {
+		a, b := workloads[i], workloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+}
+
+// networkPolicyFindings adapts AnalyzeNetworkPolicies to findings
+func networkPolicyFindings(config *ClusterConfig) []Finding {
+	result := AnalyzeNetworkPolicies(config)
+
+	var findings []Finding
+	for _, ns := range result.Namespaces {
+		var missing []string
+		if !ns.DefaultDenyIngress {
+			missing = append(missing, "ingress")
+		}
+		if !ns.DefaultDenyEgress {
+			missing = append(missing, "egress")
+		}
+		if len(missing) > 0 {
+			findings = append(findings, Finding{
+				Namespace: ns.Namespace,
+				Kind:      "Namespace",
+				Name:      ns.Namespace,
+				Rule:      "default-deny",
+				Details:   fmt.Sprintf("No default-deny %s policy", strings.Join(missing, " or ")),
+			})
+		}
+	}
+	for _, w := range result.UnselectedWorkloads {
+		findings = append(findings, Finding{
+			Namespace: w.Namespace,
+			Kind:      w.Kind,
+			Name:      w.Name,
+			Rule:      "unselected",
+			Details:   "Not selected by any NetworkPolicy",
+		})
+	}
+	for _, w := range result.OpenWorkloads {
+		findings = append(findings, Finding{
+			Severity:  SeverityHigh,
+			Namespace: w.Namespace,
+			Kind:      w.Kind,
+			Name:      w.Name,
+			Rule:      "all-namespaces",
+			Details:   "Reachable from all namespaces via " + strings.Join(w.Policies, ", "),
+		})
+	}
+	for _, p := range result.UnusedPolicies {
+		findings = append(findings, Finding{
+			Severity:  SeverityLow,
+			Namespace: p.Namespace,
+			Kind:      "NetworkPolicy",
+			Name:      p.Name,
+			Rule:      "unused",
+			Details:   "Pod selector matches no workload",
+		})
+	}
+	return findings
+}
@@ -0,0 +1,40 @@
+package kubernetes
+
+import "time"
+
+// Options configure the analyzers. The zero value runs every check with its defaults,
+// which is what the analysis stored at ingest uses unless ingest is given options.
+type Options struct {
+	// AllowedRegistries is the registry allowlist of the image check. Entries match a
+	// registry ("ghcr.io") or a registry path prefix ("ghcr.io/my-org"). When empty,
+	// registries are not checked.
+	AllowedRegistries []string
+
+	// TargetVersion is the Kubernetes minor version ("1.25") the deprecated API check
+	// plans an upgrade to. When empty, every deprecated API in the table is reported.
+	TargetVersion string
+
+	// HostPathCatalogue classifies hostPath volumes. When nil, DefaultHostPathCatalogue
+	// is used; LoadHostPathCatalogue builds one that overrides and extends it.
+	HostPathCatalogue []HostPathRule
+
+	// Now is the time the snapshot was taken, which time-based checks such as caBundle
+	// expiry measure against. When zero, the current time is used.
+	Now time.Time
+}
+
+// now returns the reference time of time-based checks
+func (o Options) now() time.Time {
+	if o.Now.IsZero() {
+		return time.Now()
+	}
+	return o.Now
+}
+
+// hostPathCatalogue returns the catalogue to classify hostPath volumes with
+func (o Options) hostPathCatalogue() []HostPathRule {
+	if o.HostPathCatalogue == nil {
+		return DefaultHostPathCatalogue
+	}
+	return o.HostPathCatalogue
+}
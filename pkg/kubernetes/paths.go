@@ -0,0 +1,406 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Types of node in an attack graph
+const (
+	PathNodeWorkload       = "workload"
+	PathNodeServiceAccount = "serviceaccount"
+	PathNodeHost           = "host"
+	PathNodeClusterAdmin   = "cluster-admin"
+)
+
+// clusterAdminNode and hostNode are the IDs of the two fixed nodes in every graph
+const (
+	clusterAdminNode = "cluster-admin"
+	hostNode         = "host"
+)
+
+// PathNode is a workload, service account, node host or cluster-admin in an attack graph
+type PathNode struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
+// PathEdge is a step an attacker holding From can take to gain To
+type PathEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+}
+
+// AttackGraph links workloads, service accounts, the node host and cluster-admin
+type AttackGraph struct {
+	Nodes []PathNode `json:"nodes"`
+	Edges []PathEdge `json:"edges"`
+}
+
+// PathStep is one node of an attack path and the reason it can be reached from the previous step
+type PathStep struct {
+	Node   PathNode `json:"node"`
+	Reason string   `json:"reason,omitempty"`
+}
+
+// AttackPath is an escalation chain from a workload to cluster-admin
+type AttackPath struct {
+	Steps []PathStep `json:"steps"`
+}
+
+// Start returns the workload the path starts from
+func (p AttackPath) Start() PathNode {
+	return p.Steps[0].Node
+}
+
+// graphBuilder collects nodes and edges, ignoring duplicates
+type graphBuilder struct {
+	graph AttackGraph
+	nodes map[string]bool
+	edges map[string]int
+}
+
+func (b *graphBuilder) addNode(id, nodeType, label string) {
+	if b.nodes[id] {
+		return
+	}
+	b.nodes[id] = true
+	b.graph.Nodes = append(b.graph.Nodes, PathNode{ID: id, Type: nodeType, Label: label})
+}
+
+// addEdge adds an edge, appending the reason to an existing edge between the same nodes
+func (b *graphBuilder) addEdge(from, to, reason string) {
+	if from == to {
+		return
+	}
+	key := from + "->" + to
+	if i, ok := b.edges[key]; ok {
+		if !strings.Contains(b.graph.Edges[i].Reason, reason) {
+			b.graph.Edges[i].Reason += "; " + reason
+		}
+		return
+	}
+	b.edges[key] = len(b.graph.Edges)
+	b.graph.Edges = append(b.graph.Edges, PathEdge{From: from, To: to, Reason: reason})
+}
+
+// addServiceAccount adds a service account node and returns its ID
+func (b *graphBuilder) addServiceAccount(namespace, name string) string {
+	id := fmt.Sprintf("sa:%s/%s", namespace, name)
+	b.addNode(id, PathNodeServiceAccount, fmt.Sprintf("ServiceAccount %s/%s", namespace, name))
+	return id
+}
+
+// pathWorkload is a workload node and the namespace its pods run in
+type pathWorkload struct {
+	id        string
+	namespace string
+}
+
+// tokenSecret is a service account token Secret and the account it belongs to
+type tokenSecret struct {
+	name    string
+	account string
+}
+
+// BuildAttackGraph links the workloads, service accounts and RBAC permissions in a
+// configuration into a graph of escalation steps:
+//   - a workload runs as its service account
+//   - a privileged, hostPID or writable hostPath workload can take over its node
+//   - a node exposes the tokens of the DaemonSets running on every node
+//   - RBAC lets a service account reach cluster-admin directly, read other service
+//     accounts' tokens, exec into or create pods, or reach nodes through nodes/proxy
+func BuildAttackGraph(config *ClusterConfig) AttackGraph {
+	b := &graphBuilder{nodes: make(map[string]bool), edges: make(map[string]int)}
+	b.addNode(hostNode, PathNodeHost, "Node host")
+	b.addNode(clusterAdminNode, PathNodeClusterAdmin, "cluster-admin")
+
+	serviceAccounts := make(map[string][]string) // namespace -> service account names
+	addAccount := func(namespace, name string) string {
+		id := b.addServiceAccount(namespace, name)
+		if !containsString(serviceAccounts[namespace], name) {
+			serviceAccounts[namespace] = append(serviceAccounts[namespace], name)
+		}
+		return id
+	}
+
+	tokenSecrets := make(map[string][]tokenSecret) // namespace -> service account token Secrets
+	enforced := make(map[string]bool)              // namespaces enforcing baseline or restricted
+	var namespaces []string
+
+	for _, item := range config.Items {
+		switch item.Kind {
+		case "ServiceAccount":
+			addAccount(namespaceOrDefault(item.Metadata.Namespace), item.Metadata.Name)
+		case "Secret":
+			var secretType string
+			item.DecodeField("type", &secretType)
+			if account := item.Metadata.Annotations["kubernetes.io/service-account.name"]; secretType == "kubernetes.io/service-account-token" && account != "" {
+				namespace := namespaceOrDefault(item.Metadata.Namespace)
+				tokenSecrets[namespace] = append(tokenSecrets[namespace], tokenSecret{name: item.Metadata.Name, account: account})
+			}
+		case "Namespace":
+			namespaces = append(namespaces, item.Metadata.Name)
+			level := item.Metadata.Labels[PSSEnforceLabel]
+			enforced[item.Metadata.Name] = level == string(PSSBaseline) || level == string(PSSRestricted)
+		}
+	}
+
+	// Workloads, their service accounts and the ways they can reach the host
+	var workloads []pathWorkload
+	for _, template := range GetPodTemplates(config) {
+		if template.IsManaged() {
+			continue // reported through the controller that created it
+		}
+
+		namespace := namespaceOrDefault(template.Namespace)
+		id := fmt.Sprintf("workload:%s/%s/%s", template.Kind, namespace, template.Name)
+		b.addNode(id, PathNodeWorkload, fmt.Sprintf("%s %s/%s", template.Kind, namespace, template.Name))
+
+		account := template.Spec.ServiceAccountName
+		if account == "" {
+			account = "default"
+		}
+		accountID := addAccount(namespace, account)
+		b.addEdge(id, accountID, "runs as service account")
+		workloads = append(workloads, pathWorkload{id: id, namespace: namespace})
+
+		for _, reason := range hostEscapes(template) {
+			b.addEdge(id, hostNode, reason)
+		}
+		if template.Kind == "DaemonSet" {
+			b.addEdge(hostNode, accountID, fmt.Sprintf("can read the token of DaemonSet %s mounted on every node", template.Name))
+		}
+	}
+
+	// Service accounts bound to roles are known before their permissions are followed
+	subjects := GetRBACPermissions(config)
+	for _, subject := range subjects {
+		if subject.Kind == "ServiceAccount" {
+			addAccount(namespaceOrDefault(subject.Namespace), subject.Name)
+		}
+	}
+
+	// accountsIn returns the service accounts in scope ("" for every namespace)
+	accountsIn := func(scope string) []string {
+		var ids []string
+		for namespace, names := range serviceAccounts {
+			if scope != "" && namespace != scope {
+				continue
+			}
+			for _, name := range names {
+				ids = append(ids, fmt.Sprintf("sa:%s/%s", namespace, name))
+			}
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	// tokensIn returns the service accounts whose tokens can be read from Secrets in scope,
+	// limited to the Secrets in resourceNames when a rule lists any. Only token Secrets in
+	// the snapshot count: since Kubernetes 1.24 service accounts no longer get one
+	// automatically, so a snapshot without Secrets yields no such steps.
+	tokensIn := func(scope string, resourceNames []string) []string {
+		var ids []string
+		for namespace, secrets := range tokenSecrets {
+			if scope != "" && namespace != scope {
+				continue
+			}
+			for _, secret := range secrets {
+				if len(resourceNames) > 0 && !containsString(resourceNames, secret.name) {
+					continue
+				}
+				ids = append(ids, b.addServiceAccount(namespace, secret.account))
+			}
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	// privilegedPodsAllowed reports whether privileged pods can be created in scope
+	privilegedPodsAllowed := func(scope string) bool {
+		if scope != "" {
+			return !enforced[scope]
+		}
+		if len(namespaces) == 0 {
+			return true
+		}
+		for _, namespace := range namespaces {
+			if !enforced[namespace] {
+				return true
+			}
+		}
+		return false
+	}
+
+	// What each service account can do with its RBAC permissions
+	for _, subject := range subjects {
+		if subject.Kind != "ServiceAccount" {
+			continue
+		}
+		accountID := b.addServiceAccount(namespaceOrDefault(subject.Namespace), subject.Name)
+
+		if subject.ClusterAdmin {
+			b.addEdge(accountID, clusterAdminNode, "bound to cluster-admin")
+		}
+		for _, risk := range subject.Risks {
+			if risk.Namespace == "" && (risk.Check == "escalate" || risk.Check == "bind" || risk.Check == "impersonate") {
+				b.addEdge(accountID, clusterAdminNode, fmt.Sprintf("can %s cluster-wide (%s)", risk.Check, risk.Role))
+			}
+		}
+
+		for _, grant := range subject.Grants {
+			scope := grant.Namespace
+			where := "cluster-wide"
+			if scope != "" {
+				where = "in " + scope
+			}
+
+			for _, rule := range grant.Rules {
+				if ruleAllows(rule, "", "secrets", "get") || ruleAllows(rule, "", "secrets", "list") {
+					for _, target := range tokensIn(scope, rule.ResourceNames) {
+						b.addEdge(accountID, target, fmt.Sprintf("can read token secrets %s (%s)", where, grant.Role))
+					}
+				}
+				// Exec and create limited by resourceNames reach only the named pods,
+				// which cannot be tied to workloads or service accounts
+				if ruleAllows(rule, "", "pods/exec", "create") && len(rule.ResourceNames) == 0 {
+					for _, w := range workloads {
+						if scope == "" || w.namespace == scope {
+							b.addEdge(accountID, w.id, fmt.Sprintf("can exec into pods %s (%s)", where, grant.Role))
+						}
+					}
+				}
+				if ruleAllows(rule, "", "pods", "create") && len(rule.ResourceNames) == 0 {
+					for _, target := range accountsIn(scope) {
+						b.addEdge(accountID, target, fmt.Sprintf("can create pods %s running as any service account (%s)", where, grant.Role))
+					}
+					if privilegedPodsAllowed(scope) {
+						b.addEdge(accountID, hostNode, fmt.Sprintf("can create privileged pods %s (%s)", where, grant.Role))
+					}
+				}
+				if scope == "" && (ruleAllows(rule, "", "nodes/proxy", "get") || ruleAllows(rule, "", "nodes/proxy", "create")) {
+					b.addEdge(accountID, hostNode, fmt.Sprintf("can run commands through the kubelet API with nodes/proxy (%s)", grant.Role))
+				}
+			}
+		}
+	}
+
+	return b.graph
+}
+
+// hostEscapes lists the settings that let a workload take over the node it runs on
+func hostEscapes(template PodTemplate) []string {
+	var reasons []string
+	for _, container := range template.Spec.PodContainers() {
+		if container.IsPrivileged() {
+			reasons = append(reasons, fmt.Sprintf("privileged container %s", container.Name))
+		}
+	}
+	if template.Spec.HostPID {
+		reasons = append(reasons, "shares the host PID namespace")
+	}
+	for _, volume := range template.Spec.Volumes {
+		if volume.HostPath != nil && !isMountedReadOnly(template.Spec.PodContainers(), volume.Name) {
+			reasons = append(reasons, fmt.Sprintf("writable hostPath %s", volume.HostPath.Path))
+		}
+	}
+	return reasons
+}
+
+// namespaceOrDefault returns the namespace, treating an empty one as "default"
+func namespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return "default"
+	}
+	return namespace
+}
+
+// FindAttackPaths returns the shortest path from every workload that can reach
+// cluster-admin, shortest paths first
+func FindAttackPaths(graph AttackGraph) []AttackPath {
+	nodes := make(map[string]PathNode, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		nodes[node.ID] = node
+	}
+	outgoing := make(map[string][]PathEdge)
+	for _, edge := range graph.Edges {
+		outgoing[edge.From] = append(outgoing[edge.From], edge)
+	}
+
+	var paths []AttackPath
+	for _, start := range graph.Nodes {
+		if start.Type != PathNodeWorkload {
+			continue
+		}
+
+		// Breadth-first search, remembering the edge used to reach each node
+		via := map[string]PathEdge{start.ID: {}}
+		queue := []string{start.ID}
+		for len(queue) > 0 && !hasKey(via, clusterAdminNode) {
+			current := queue[0]
+			queue = queue[1:]
+			for _, edge := range outgoing[current] {
+				if hasKey(via, edge.To) {
+					continue
+				}
+				via[edge.To] = edge
+				queue = append(queue, edge.To)
+			}
+		}
+		if !hasKey(via, clusterAdminNode) {
+			continue
+		}
+
+		var steps []PathStep
+		for id := clusterAdminNode; id != start.ID; id = via[id].From {
+			steps = append([]PathStep{{Node: nodes[id], Reason: via[id].Reason}}, steps...)
+		}
+		steps = append([]PathStep{{Node: start}}, steps...)
+		paths = append(paths, AttackPath{Steps: steps})
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		if len(paths[i].Steps) != len(paths[j].Steps) {
+			return len(paths[i].Steps) < len(paths[j].Steps)
+		}
+		return paths[i].Start().Label < paths[j].Start().Label
+	})
+	return paths
+}
+
+// hasKey reports whether a node has been reached
+func hasKey(via map[string]PathEdge, id string) bool {
+	_, ok := via[id]
+	return ok
+}
+
+// PathsGraph returns the part of a graph used by the given paths
+func PathsGraph(graph AttackGraph, paths []AttackPath) AttackGraph {
+	used := make(map[string]bool)
+	edges := make(map[string]bool)
+	for _, path := range paths {
+		for i, step := range path.Steps {
+			used[step.Node.ID] = true
+			if i > 0 {
+				edges[path.Steps[i-1].Node.ID+"->"+step.Node.ID] = true
+			}
+		}
+	}
+
+	var subgraph AttackGraph
+	for _, node := range graph.Nodes {
+		if used[node.ID] {
+			subgraph.Nodes = append(subgraph.Nodes, node)
+		}
+	}
+	for _, edge := range graph.Edges {
+		if edges[edge.From+"->"+edge.To] {
+			subgraph.Edges = append(subgraph.Edges, edge)
+		}
+	}
+	return subgraph
+}
@@ -0,0 +1,426 @@
+package kubernetes
+
+import (
+	"encoding/json"
+)
+
+// workloadKind describes a resource kind that carries a pod template
+type workloadKind struct {
+	// priority is used when the same container is reported by several owners;
+	// the highest priority kind wins (Deployment over ReplicaSet over Pod)
+	priority int
+	// templatePath is the path from the resource spec to its pod template.
+	// An empty path means the spec is itself a pod spec (Pod).
+	templatePath []string
+}
+
+// workloadKinds is the single list of resource kinds analyzers inspect for pod specs.
+// A new workload kind only needs to be added here.
+var workloadKinds = map[string]workloadKind{
+	"Pod":                   {priority: 1},
+	"ReplicationController": {priority: 2, templatePath: []string{"template"}},
+	"ReplicaSet":            {priority: 2, templatePath: []string{"template"}},
+	"Job":                   {priority: 3, templatePath: []string{"template"}},
+	"CronJob":               {priority: 4, templatePath: []string{"jobTemplate", "spec", "template"}},
+	"DaemonSet":             {priority: 5, templatePath: []string{"template"}},
+	"StatefulSet":           {priority: 6, templatePath: []string{"template"}},
+	"Deployment":            {priority: 7, templatePath: []string{"template"}},
+}
+
+// PodTemplate is the pod specification of a workload resource in typed form
+type PodTemplate struct {
+	Kind            string // kind of the resource the template belongs to
+	Name            string
+	Namespace       string
+	Labels          map[string]string // labels applied to the pods
+	Annotations     map[string]string // annotations applied to the pods
+	OwnerReferences []OwnerReference  // owners of the resource itself
+	Source          string
+	Spec            PodSpec
+}
+
+// PodSpec is the subset of a Kubernetes pod spec used by the analyzers
+type PodSpec struct {
+	Containers          []Container         `json:"containers,omitempty"`
+	InitContainers      []Container         `json:"initContainers,omitempty"`
+	EphemeralContainers []Container         `json:"ephemeralContainers,omitempty"`
+	Volumes             []Volume            `json:"volumes,omitempty"`
+	HostPID             bool                `json:"hostPID,omitempty"`
+	HostIPC             bool                `json:"hostIPC,omitempty"`
+	HostNetwork         bool                `json:"hostNetwork,omitempty"`
+	ServiceAccountName  string              `json:"serviceAccountName,omitempty"`
+	ImagePullSecrets    []LocalObjectRef    `json:"imagePullSecrets,omitempty"`
+	NodeName            string              `json:"nodeName,omitempty"`
+	NodeSelector        map[string]string   `json:"nodeSelector,omitempty"`
+	Tolerations         []Toleration        `json:"tolerations,omitempty"`
+	SecurityContext     *PodSecurityContext `json:"securityContext,omitempty"`
+	Affinity            *Affinity           `json:"affinity,omitempty"`
+	// TopologySpreadConstraints spread the pods across zones or nodes
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// AutomountServiceAccountToken overrides the service account's setting when set
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+}
+
+// Container is a container, init container or ephemeral container definition
+type Container struct {
+	Name            string           `json:"name,omitempty"`
+	Image           string           `json:"image,omitempty"`
+	Ports           []ContainerPort  `json:"ports,omitempty"`
+	VolumeMounts    []VolumeMount    `json:"volumeMounts,omitempty"`
+	Resources       Resources        `json:"resources,omitempty"`
+	SecurityContext *SecurityContext `json:"securityContext,omitempty"`
+	ReadinessProbe  *Probe           `json:"readinessProbe,omitempty"`
+	LivenessProbe   *Probe           `json:"livenessProbe,omitempty"`
+	Env             []EnvVar         `json:"env,omitempty"`
+	EnvFrom         []EnvFromSource  `json:"envFrom,omitempty"`
+}
+
+// LocalObjectRef names an object in the same namespace, optionally allowing it to be missing
+type LocalObjectRef struct {
+	Name     string `json:"name,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// EnvVar is a container environment variable with a literal value or a reference
+// to a ConfigMap or Secret key
+type EnvVar struct {
+	Name      string `json:"name,omitempty"`
+	Value     string `json:"value,omitempty"`
+	ValueFrom *struct {
+		ConfigMapKeyRef *LocalObjectRef `json:"configMapKeyRef,omitempty"`
+		SecretKeyRef    *LocalObjectRef `json:"secretKeyRef,omitempty"`
+	} `json:"valueFrom,omitempty"`
+}
+
+// EnvFromSource imports every key of a ConfigMap or Secret as environment variables
+type EnvFromSource struct {
+	ConfigMapRef *LocalObjectRef `json:"configMapRef,omitempty"`
+	SecretRef    *LocalObjectRef `json:"secretRef,omitempty"`
+}
+
+// Probe is a container health check. Only its presence and timing are used.
+type Probe struct {
+	InitialDelaySeconds int `json:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       int `json:"periodSeconds,omitempty"`
+	TimeoutSeconds      int `json:"timeoutSeconds,omitempty"`
+	FailureThreshold    int `json:"failureThreshold,omitempty"`
+}
+
+// Resources holds a container's compute resource requests and limits
+type Resources struct {
+	Requests map[string]Quantity `json:"requests,omitempty"`
+	Limits   map[string]Quantity `json:"limits,omitempty"`
+}
+
+// ContainerPort is a port exposed by a container
+type ContainerPort struct {
+	Name          string `json:"name,omitempty"`
+	ContainerPort int    `json:"containerPort,omitempty"`
+	HostPort      int    `json:"hostPort,omitempty"`
+	Protocol      string `json:"protocol,omitempty"`
+}
+
+// VolumeMount mounts a pod volume into a container
+type VolumeMount struct {
+	Name      string `json:"name,omitempty"`
+	MountPath string `json:"mountPath,omitempty"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+}
+
+// SecurityContext holds container level security settings
+type SecurityContext struct {
+	Privileged               *bool            `json:"privileged,omitempty"`
+	AllowPrivilegeEscalation *bool            `json:"allowPrivilegeEscalation,omitempty"`
+	Capabilities             *Capabilities    `json:"capabilities,omitempty"`
+	RunAsUser                *int64           `json:"runAsUser,omitempty"`
+	RunAsNonRoot             *bool            `json:"runAsNonRoot,omitempty"`
+	ReadOnlyRootFilesystem   *bool            `json:"readOnlyRootFilesystem,omitempty"`
+	ProcMount                string           `json:"procMount,omitempty"`
+	SeccompProfile           *SeccompProfile  `json:"seccompProfile,omitempty"`
+	AppArmorProfile          *AppArmorProfile `json:"appArmorProfile,omitempty"`
+	SELinuxOptions           *SELinuxOptions  `json:"seLinuxOptions,omitempty"`
+	WindowsOptions           *WindowsOptions  `json:"windowsOptions,omitempty"`
+}
+
+// PodSecurityContext holds pod level security settings
+type PodSecurityContext struct {
+	RunAsUser       *int64           `json:"runAsUser,omitempty"`
+	RunAsNonRoot    *bool            `json:"runAsNonRoot,omitempty"`
+	FSGroup         *int64           `json:"fsGroup,omitempty"`
+	SeccompProfile  *SeccompProfile  `json:"seccompProfile,omitempty"`
+	AppArmorProfile *AppArmorProfile `json:"appArmorProfile,omitempty"`
+	SELinuxOptions  *SELinuxOptions  `json:"seLinuxOptions,omitempty"`
+	WindowsOptions  *WindowsOptions  `json:"windowsOptions,omitempty"`
+	Sysctls         []Sysctl         `json:"sysctls,omitempty"`
+}
+
+// SeccompProfile selects the seccomp profile applied to a pod or container
+type SeccompProfile struct {
+	Type             string `json:"type,omitempty"`
+	LocalhostProfile string `json:"localhostProfile,omitempty"`
+}
+
+// AppArmorProfile selects the AppArmor profile applied to a pod or container
+type AppArmorProfile struct {
+	Type             string `json:"type,omitempty"`
+	LocalhostProfile string `json:"localhostProfile,omitempty"`
+}
+
+// SELinuxOptions are the SELinux labels applied to a pod or container
+type SELinuxOptions struct {
+	User  string `json:"user,omitempty"`
+	Role  string `json:"role,omitempty"`
+	Type  string `json:"type,omitempty"`
+	Level string `json:"level,omitempty"`
+}
+
+// WindowsOptions holds Windows specific security settings
+type WindowsOptions struct {
+	HostProcess *bool `json:"hostProcess,omitempty"`
+}
+
+// Sysctl is a namespaced kernel parameter set for a pod
+type Sysctl struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Toleration allows a pod to schedule onto nodes with a matching taint
+type Toleration struct {
+	Key      string `json:"key,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+// Affinity holds a pod's scheduling affinity rules. Only node affinity and pod
+// anti-affinity are used.
+type Affinity struct {
+	NodeAffinity    *NodeAffinity    `json:"nodeAffinity,omitempty"`
+	PodAntiAffinity *PodAntiAffinity `json:"podAntiAffinity,omitempty"`
+}
+
+// NodeAffinity restricts or steers a pod to nodes with matching labels
+type NodeAffinity struct {
+	Required *struct {
+		NodeSelectorTerms []NodeSelectorTerm `json:"nodeSelectorTerms,omitempty"`
+	} `json:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
+	Preferred []struct {
+		Weight     int              `json:"weight,omitempty"`
+		Preference NodeSelectorTerm `json:"preference"`
+	} `json:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
+}
+
+// NodeSelectorTerm matches nodes by label (MatchExpressions) or by field such as
+// metadata.name (MatchFields); all of its requirements must hold
+type NodeSelectorTerm struct {
+	MatchExpressions []NodeSelectorRequirement `json:"matchExpressions,omitempty"`
+	MatchFields      []NodeSelectorRequirement `json:"matchFields,omitempty"`
+}
+
+// NodeSelectorRequirement is a key, an operator (In, NotIn, Exists, DoesNotExist, Gt
+// or Lt) and values
+type NodeSelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// PodAntiAffinity keeps a pod away from nodes or zones running matching pods
+type PodAntiAffinity struct {
+	Required  []interface{} `json:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
+	Preferred []interface{} `json:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
+}
+
+// TopologySpreadConstraint spreads matching pods across a topology domain
+type TopologySpreadConstraint struct {
+	MaxSkew           int    `json:"maxSkew,omitempty"`
+	TopologyKey       string `json:"topologyKey,omitempty"`
+	WhenUnsatisfiable string `json:"whenUnsatisfiable,omitempty"`
+}
+
+// Capabilities lists Linux capabilities added to or dropped from a container
+type Capabilities struct {
+	Add  []string `json:"add,omitempty"`
+	Drop []string `json:"drop,omitempty"`
+}
+
+// Volume is a pod volume. Only the volume sources analyzers care about are decoded;
+// Source records the type of any volume (e.g. "hostPath", "emptyDir").
+type Volume struct {
+	Name     string                `json:"name,omitempty"`
+	Source   string                `json:"-"`
+	HostPath *HostPathVolumeSource `json:"hostPath,omitempty"`
+	// ConfigMap, Secret, PersistentVolumeClaim and Projected reference other objects
+	ConfigMap             *LocalObjectRef     `json:"configMap,omitempty"`
+	Secret                *SecretVolumeSource `json:"secret,omitempty"`
+	PersistentVolumeClaim *struct {
+		ClaimName string `json:"claimName,omitempty"`
+	} `json:"persistentVolumeClaim,omitempty"`
+	Projected *struct {
+		Sources []struct {
+			ConfigMap *LocalObjectRef `json:"configMap,omitempty"`
+			Secret    *LocalObjectRef `json:"secret,omitempty"`
+		} `json:"sources,omitempty"`
+	} `json:"projected,omitempty"`
+}
+
+// SecretVolumeSource is a volume populated from a Secret
+type SecretVolumeSource struct {
+	SecretName string `json:"secretName,omitempty"`
+	Optional   bool   `json:"optional,omitempty"`
+}
+
+// UnmarshalJSON decodes a volume and records which volume source it uses
+func (v *Volume) UnmarshalJSON(data []byte) error {
+	type plain Volume
+	if err := json.Unmarshal(data, (*plain)(v)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for key := range fields {
+		if key != "name" {
+			v.Source = key
+			break
+		}
+	}
+	return nil
+}
+
+// HostPathVolumeSource is a volume backed by a path on the node
+type HostPathVolumeSource struct {
+	Path string `json:"path,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// IsWorkloadKind reports whether resources of this kind carry a pod template
+func IsWorkloadKind(kind string) bool {
+	_, ok := workloadKinds[kind]
+	return ok
+}
+
+// IsControllerKind reports whether resources of this kind create pods from a template
+func IsControllerKind(kind string) bool {
+	return kind != "Pod" && IsWorkloadKind(kind)
+}
+
+// workloadPriority returns the priority used to pick between owners of the same container
+func workloadPriority(kind string) int {
+	return workloadKinds[kind].priority
+}
+
+// IsPrivileged reports whether the container runs privileged
+func (c Container) IsPrivileged() bool {
+	return c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged
+}
+
+// AddedCapabilities returns the Linux capabilities added to the container
+func (c Container) AddedCapabilities() []string {
+	if c.SecurityContext == nil || c.SecurityContext.Capabilities == nil {
+		return nil
+	}
+	return c.SecurityContext.Capabilities.Add
+}
+
+// AllContainers returns the regular, init and ephemeral containers of the pod
+func (s PodSpec) AllContainers() []Container {
+	containers := s.PodContainers()
+	return append(containers, s.EphemeralContainers...)
+}
+
+// PodContainers returns the regular and init containers of the pod
+func (s PodSpec) PodContainers() []Container {
+	containers := make([]Container, 0, len(s.Containers)+len(s.InitContainers))
+	containers = append(containers, s.Containers...)
+	return append(containers, s.InitContainers...)
+}
+
+// IsManaged reports whether the template belongs to a workload created by a controller,
+// such as a Pod of a ReplicaSet or a ReplicaSet of a Deployment. Analyzers skip these
+// and report the controller's template instead.
+func (t PodTemplate) IsManaged() bool {
+	for _, ref := range t.OwnerReferences {
+		if IsControllerKind(ref.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPodTemplate extracts the typed pod template from a workload resource.
+// It returns false for resources that are not workloads or whose spec cannot be decoded.
+func GetPodTemplate(item Item) (PodTemplate, bool) {
+	kind, ok := workloadKinds[item.Kind]
+	if !ok {
+		return PodTemplate{}, false
+	}
+
+	template := PodTemplate{
+		Kind:            item.Kind,
+		Name:            item.Metadata.Name,
+		Namespace:       item.Metadata.Namespace,
+		Labels:          item.Metadata.Labels,
+		Annotations:     item.Metadata.Annotations,
+		OwnerReferences: item.Metadata.OwnerReferences,
+		Source:          item.Source,
+	}
+
+	spec, ok := item.Spec.(map[string]interface{})
+	if !ok {
+		return PodTemplate{}, false
+	}
+
+	if len(kind.templatePath) > 0 {
+		podTemplate := spec
+		for _, key := range kind.templatePath {
+			next, ok := podTemplate[key].(map[string]interface{})
+			if !ok {
+				return PodTemplate{}, false
+			}
+			podTemplate = next
+		}
+
+		var metadata Metadata
+		if raw, ok := podTemplate["metadata"]; ok && decodeInto(raw, &metadata) == nil {
+			template.Labels = metadata.Labels
+			template.Annotations = metadata.Annotations
+		} else {
+			template.Labels = nil
+			template.Annotations = nil
+		}
+
+		if spec, ok = podTemplate["spec"].(map[string]interface{}); !ok {
+			return PodTemplate{}, false
+		}
+	}
+
+	if err := decodeInto(spec, &template.Spec); err != nil {
+		return PodTemplate{}, false
+	}
+
+	return template, true
+}
+
+// GetPodTemplates returns the pod templates of every workload in the configuration
+func GetPodTemplates(config *ClusterConfig) []PodTemplate {
+	var templates []PodTemplate
+	for _, item := range config.Items {
+		if template, ok := GetPodTemplate(item); ok {
+			templates = append(templates, template)
+		}
+	}
+	return templates
+}
+
+// decodeInto converts generic decoded JSON into a typed value
+func decodeInto(value interface{}, target interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
@@ -0,0 +1,383 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PSSLevel is a Pod Security Standards profile
+type PSSLevel string
+
+const (
+	PSSPrivileged PSSLevel = "privileged"
+	PSSBaseline   PSSLevel = "baseline"
+	PSSRestricted PSSLevel = "restricted"
+)
+
+// PSSEnforceLabel is the namespace label that sets the enforced Pod Security level
+const PSSEnforceLabel = "pod-security.kubernetes.io/enforce"
+
+// PSSViolation is a single control a pod template fails
+type PSSViolation struct {
+	Level     PSSLevel // lowest profile that forbids this setting
+	Check     string   // control name, following the upstream policy names
+	Container string   // empty for pod level settings
+	Subject   string   // what a control failed more than once is about, e.g. a sysctl or volume
+	Detail    string
+}
+
+// PSSWorkload is the Pod Security Standards evaluation of one workload
+type PSSWorkload struct {
+	Name       string
+	Namespace  string
+	Kind       string
+	Level      PSSLevel // highest profile the workload satisfies
+	Violations []PSSViolation
+}
+
+// PSSNamespace summarises the profiles a namespace's workloads could be held to
+type PSSNamespace struct {
+	Namespace            string
+	Workloads            int
+	CurrentEnforce       string   // value of the enforce label, if set
+	Level                PSSLevel // highest profile every workload in the namespace satisfies
+	BaselineViolations   int      // workloads failing baseline
+	RestrictedViolations int      // workloads meeting baseline but failing restricted
+}
+
+// PSSResult is the Pod Security Standards evaluation of a configuration
+type PSSResult struct {
+	Workloads  []PSSWorkload
+	Namespaces []PSSNamespace
+}
+
+// baselineCapabilities may be added under the baseline profile
+var baselineCapabilities = map[string]bool{
+	"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true,
+	"FSETID": true, "KILL": true, "MKNOD": true, "NET_BIND_SERVICE": true,
+	"SETFCAP": true, "SETGID": true, "SETPCAP": true, "SETUID": true, "SYS_CHROOT": true,
+}
+
+// safeSysctls may be set under the baseline profile
+var safeSysctls = map[string]bool{
+	"kernel.shm_rmid_forced":              true,
+	"net.ipv4.ip_local_port_range":        true,
+	"net.ipv4.ip_local_reserved_ports":    true,
+	"net.ipv4.ip_unprivileged_port_start": true,
+	"net.ipv4.tcp_syncookies":             true,
+	"net.ipv4.ping_group_range":           true,
+	"net.ipv4.tcp_keepalive_time":         true,
+	"net.ipv4.tcp_fin_timeout":            true,
+	"net.ipv4.tcp_keepalive_intvl":        true,
+	"net.ipv4.tcp_keepalive_probes":       true,
+}
+
+// restrictedVolumeTypes are the only volume sources allowed under the restricted profile
+var restrictedVolumeTypes = map[string]bool{
+	"configMap": true, "csi": true, "downwardAPI": true, "emptyDir": true,
+	"ephemeral": true, "persistentVolumeClaim": true, "projected": true, "secret": true,
+}
+
+// allowedSELinuxTypes may be set under the baseline profile
+var allowedSELinuxTypes = map[string]bool{
+	"": true, "container_t": true, "container_init_t": true, "container_kvm_t": true, "container_engine_t": true,
+}
+
+// appArmorAnnotationPrefix is the legacy per-container AppArmor annotation
+const appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+
+// EvaluatePodSecurityStandards checks every workload against the baseline and
+// restricted Pod Security Standards and works out the highest profile each
+// namespace could enforce. Pods created by a controller are covered by the controller.
+func EvaluatePodSecurityStandards(config *ClusterConfig) PSSResult {
+	var result PSSResult
+	namespaces := make(map[string]*PSSNamespace)
+
+	// Namespaces with no workloads can enforce restricted
+	for _, item := range config.Items {
+		if item.Kind == "Namespace" {
+			namespaces[item.Metadata.Name] = &PSSNamespace{
+				Namespace:      item.Metadata.Name,
+				CurrentEnforce: item.Metadata.Labels[PSSEnforceLabel],
+				Level:          PSSRestricted,
+			}
+		}
+	}
+
+	for _, template := range GetPodTemplates(config) {
+		if template.IsManaged() {
+			continue // ReplicaSets and Pods are evaluated through the controller that created them
+		}
+
+		workload := evaluatePodTemplate(template)
+		result.Workloads = append(result.Workloads, workload)
+
+		ns, ok := namespaces[workload.Namespace]
+		if !ok {
+			ns = &PSSNamespace{Namespace: workload.Namespace, Level: PSSRestricted}
+			namespaces[workload.Namespace] = ns
+		}
+		ns.Workloads++
+		switch workload.Level {
+		case PSSPrivileged:
+			ns.BaselineViolations++
+		case PSSBaseline:
+			ns.RestrictedViolations++
+		}
+		if pssRank(workload.Level) < pssRank(ns.Level) {
+			ns.Level = workload.Level
+		}
+	}
+
+	for _, ns := range namespaces {
+		result.Namespaces = append(result.Namespaces, *ns)
+	}
+	sort.Slice(result.Namespaces, func(i, j int) bool {
+		return result.Namespaces[i].Namespace < result.Namespaces[j].Namespace
+	})
+	sort.Slice(result.Workloads, func(i, j int) bool {
+		wi, wj := result.Workloads[i], result.Workloads[j]
+		if wi.Namespace != wj.Namespace {
+			return wi.Namespace < wj.Namespace
+		}
+		if wi.Kind != wj.Kind {
+			return wi.Kind < wj.Kind
+		}
+		return wi.Name < wj.Name
+	})
+
+	return result
+}
+
+// pssRank orders profiles from least to most restrictive
+func pssRank(level PSSLevel) int {
+	switch level {
+	case PSSRestricted:
+		return 2
+	case PSSBaseline:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// evaluatePodTemplate checks one pod template against both profiles
+func evaluatePodTemplate(template PodTemplate) PSSWorkload {
+	spec := template.Spec
+	workload := PSSWorkload{
+		Name:      template.Name,
+		Namespace: template.Namespace,
+		Kind:      template.Kind,
+	}
+
+	addFor := func(level PSSLevel, check, container, subject, detail string) {
+		workload.Violations = append(workload.Violations, PSSViolation{
+			Level:     level,
+			Check:     check,
+			Container: container,
+			Subject:   subject,
+			Detail:    detail,
+		})
+	}
+	add := func(level PSSLevel, check, container, detail string) {
+		addFor(level, check, container, "", detail)
+	}
+
+	podSC := spec.SecurityContext
+	if podSC == nil {
+		podSC = &PodSecurityContext{}
+	}
+
+	// Baseline: pod level controls
+	if spec.HostNetwork || spec.HostPID || spec.HostIPC {
+		var used []string
+		if spec.HostNetwork {
+			used = append(used, "hostNetwork")
+		}
+		if spec.HostPID {
+			used = append(used, "hostPID")
+		}
+		if spec.HostIPC {
+			used = append(used, "hostIPC")
+		}
+		add(PSSBaseline, "Host Namespaces", "", strings.Join(used, ", ")+" must not be set")
+	}
+	if podSC.WindowsOptions != nil && isTrue(podSC.WindowsOptions.HostProcess) {
+		add(PSSBaseline, "HostProcess", "", "windowsOptions.hostProcess must not be true")
+	}
+	if podSC.SeccompProfile != nil && podSC.SeccompProfile.Type == "Unconfined" {
+		add(PSSBaseline, "Seccomp", "", "pod seccompProfile.type must not be Unconfined")
+	}
+	if podSC.AppArmorProfile != nil && podSC.AppArmorProfile.Type == "Unconfined" {
+		add(PSSBaseline, "AppArmor", "", "pod appArmorProfile.type must not be Unconfined")
+	}
+	if detail := seLinuxViolation(podSC.SELinuxOptions); detail != "" {
+		add(PSSBaseline, "SELinux", "", "pod "+detail)
+	}
+	for _, sysctl := range podSC.Sysctls {
+		if !safeSysctls[sysctl.Name] {
+			addFor(PSSBaseline, "Sysctls", "", sysctl.Name, fmt.Sprintf("sysctl %s is not in the safe set", sysctl.Name))
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if volume.Source == "hostPath" {
+			addFor(PSSBaseline, "HostPath Volumes", "", volume.Name, fmt.Sprintf("volume %s uses hostPath", volume.Name))
+		} else if volume.Source != "" && !restrictedVolumeTypes[volume.Source] {
+			addFor(PSSRestricted, "Volume Types", "", volume.Name, fmt.Sprintf("volume %s uses %s", volume.Name, volume.Source))
+		}
+	}
+	for key, value := range template.Annotations {
+		if strings.HasPrefix(key, appArmorAnnotationPrefix) && value != "runtime/default" && !strings.HasPrefix(value, "localhost/") {
+			add(PSSBaseline, "AppArmor", strings.TrimPrefix(key, appArmorAnnotationPrefix), "AppArmor annotation is "+value)
+		}
+	}
+
+	// Container level controls, including init and ephemeral containers
+	for _, c := range spec.AllContainers() {
+		sc := c.SecurityContext
+		if sc == nil {
+			sc = &SecurityContext{}
+		}
+
+		// Baseline
+		if isTrue(sc.Privileged) {
+			add(PSSBaseline, "Privileged Containers", c.Name, "privileged must not be true")
+		}
+		if sc.WindowsOptions != nil && isTrue(sc.WindowsOptions.HostProcess) {
+			add(PSSBaseline, "HostProcess", c.Name, "windowsOptions.hostProcess must not be true")
+		}
+		var disallowed []string
+		for _, capability := range c.AddedCapabilities() {
+			if !baselineCapabilities[strings.TrimPrefix(strings.ToUpper(capability), "CAP_")] {
+				disallowed = append(disallowed, capability)
+			}
+		}
+		if len(disallowed) > 0 {
+			add(PSSBaseline, "Capabilities", c.Name, "adds "+strings.Join(disallowed, ", "))
+		}
+		for _, port := range c.Ports {
+			if port.HostPort != 0 {
+				addFor(PSSBaseline, "Host Ports", c.Name, fmt.Sprint(port.HostPort), fmt.Sprintf("hostPort %d", port.HostPort))
+			}
+		}
+		if sc.ProcMount != "" && sc.ProcMount != "Default" {
+			add(PSSBaseline, "/proc Mount Type", c.Name, "procMount is "+sc.ProcMount)
+		}
+		if sc.SeccompProfile != nil && sc.SeccompProfile.Type == "Unconfined" {
+			add(PSSBaseline, "Seccomp", c.Name, "seccompProfile.type must not be Unconfined")
+		}
+		if sc.AppArmorProfile != nil && sc.AppArmorProfile.Type == "Unconfined" {
+			add(PSSBaseline, "AppArmor", c.Name, "appArmorProfile.type must not be Unconfined")
+		}
+		if detail := seLinuxViolation(sc.SELinuxOptions); detail != "" {
+			add(PSSBaseline, "SELinux", c.Name, detail)
+		}
+
+		// Restricted
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			add(PSSRestricted, "Privilege Escalation", c.Name, "allowPrivilegeEscalation must be false")
+		}
+
+		runAsNonRoot := podSC.RunAsNonRoot
+		if sc.RunAsNonRoot != nil {
+			runAsNonRoot = sc.RunAsNonRoot
+		}
+		if !isTrue(runAsNonRoot) {
+			add(PSSRestricted, "Running as Non-root", c.Name, "runAsNonRoot must be true")
+		}
+
+		runAsUser := podSC.RunAsUser
+		if sc.RunAsUser != nil {
+			runAsUser = sc.RunAsUser
+		}
+		if runAsUser != nil && *runAsUser == 0 {
+			add(PSSRestricted, "Running as Non-root user", c.Name, "runAsUser must not be 0")
+		}
+
+		seccomp := podSC.SeccompProfile
+		if sc.SeccompProfile != nil {
+			seccomp = sc.SeccompProfile
+		}
+		if seccomp == nil || (seccomp.Type != "RuntimeDefault" && seccomp.Type != "Localhost") {
+			add(PSSRestricted, "Seccomp", c.Name, "seccompProfile.type must be RuntimeDefault or Localhost")
+		}
+
+		if !dropsAllCapabilities(sc.Capabilities) {
+			add(PSSRestricted, "Capabilities", c.Name, "capabilities must drop ALL")
+		}
+		for _, capability := range c.AddedCapabilities() {
+			if strings.TrimPrefix(strings.ToUpper(capability), "CAP_") != "NET_BIND_SERVICE" {
+				addFor(PSSRestricted, "Capabilities", c.Name, capability, "may only add NET_BIND_SERVICE, adds "+capability)
+			}
+		}
+	}
+
+	// The workload satisfies the highest profile it has no violations for
+	workload.Level = PSSRestricted
+	for _, v := range workload.Violations {
+		if v.Level == PSSBaseline {
+			workload.Level = PSSPrivileged
+			break
+		}
+		workload.Level = PSSBaseline
+	}
+
+	return workload
+}
+
+// seLinuxViolation describes SELinux options the baseline profile does not allow
+func seLinuxViolation(options *SELinuxOptions) string {
+	if options == nil {
+		return ""
+	}
+	if !allowedSELinuxTypes[options.Type] {
+		return "seLinuxOptions.type " + options.Type + " is not allowed"
+	}
+	if options.User != "" || options.Role != "" {
+		return "seLinuxOptions.user and role must not be set"
+	}
+	return ""
+}
+
+// dropsAllCapabilities reports whether a container drops every capability
+func dropsAllCapabilities(capabilities *Capabilities) bool {
+	if capabilities == nil {
+		return false
+	}
+	for _, capability := range capabilities.Drop {
+		if strings.ToUpper(capability) == "ALL" {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrue reports whether an optional boolean is set to true
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+// pssFindings adapts EvaluatePodSecurityStandards to findings, one per violation.
+// Baseline violations are reported as high, restricted only violations as medium.
+func pssFindings(config *ClusterConfig) []Finding {
+	var findings []Finding
+	for _, workload := range EvaluatePodSecurityStandards(config).Workloads {
+		for _, v := range workload.Violations {
+			severity := SeverityMedium
+			if v.Level == PSSBaseline {
+				severity = SeverityHigh
+			}
+			findings = append(findings, Finding{
+				Severity:  severity,
+				Namespace: workload.Namespace,
+				Kind:      workload.Kind,
+				Name:      workload.Name,
+				Container: v.Container,
+				Rule:      strings.TrimSpace(fmt.Sprintf("%s %s %s", v.Level, v.Check, v.Subject)),
+				Details:   fmt.Sprintf("%s: %s (%s)", v.Level, v.Check, v.Detail),
+			})
+		}
+	}
+	return findings
+}
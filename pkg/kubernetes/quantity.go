@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Quantity is a Kubernetes resource quantity such as "500m", "2" or "128Mi".
+// Manifests may give plain numbers, so numbers are accepted as well as strings.
+type Quantity string
+
+// UnmarshalJSON decodes a quantity from a JSON string or number
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*q = Quantity(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid quantity %s", data)
+	}
+	*q = Quantity(n.String())
+	return nil
+}
+
+// Value returns the quantity in base units (cores for CPU, bytes for memory)
+func (q Quantity) Value() (float64, error) {
+	return ParseQuantity(string(q))
+}
+
+// quantitySuffixes maps the suffixes of the Kubernetes quantity format to multipliers
+var quantitySuffixes = map[string]float64{
+	"n": 1e-9, "u": 1e-6, "m": 1e-3,
+	"k": 1e3, "M": 1e6, "G": 1e9, "T": 1e12, "P": 1e15, "E": 1e18,
+	"Ki": 1 << 10, "Mi": 1 << 20, "Gi": 1 << 30, "Ti": 1 << 40, "Pi": 1 << 50, "Ei": 1 << 60,
+}
+
+// ParseQuantity parses a Kubernetes resource quantity into base units, e.g.
+// "500m" is 0.5 and "1Gi" is 1073741824
+func ParseQuantity(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty quantity")
+	}
+
+	// Binary suffixes are two characters, decimal suffixes one
+	number, multiplier := s, 1.0
+	if len(s) > 2 {
+		if m, ok := quantitySuffixes[s[len(s)-2:]]; ok {
+			number, multiplier = s[:len(s)-2], m
+		}
+	}
+	if multiplier == 1 && len(s) > 1 {
+		if m, ok := quantitySuffixes[s[len(s)-1:]]; ok {
+			number, multiplier = s[:len(s)-1], m
+		}
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	return value * multiplier, nil
+}
@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"1", 1},
+		{"0.5", 0.5},
+		{"500m", 0.5},
+		{"100m", 0.1},
+		{"250u", 0.00025},
+		{"1k", 1000},
+		{"1M", 1e6},
+		{"2G", 2e9},
+		{"1T", 1e12},
+		{"1Ki", 1024},
+		{"128Mi", 128 << 20},
+		{"1.5Gi", 1.5 * (1 << 30)},
+		{"1Ti", 1 << 40},
+		{"1e3", 1000},
+		{"1E3", 1000},
+		{"5e-3", 0.005},
+		{"1E", 1e18},
+		{" 2Gi ", 2 << 30},
+	}
+	for _, tt := range tests {
+		got, err := ParseQuantity(tt.in)
+		if err != nil {
+			t.Errorf("ParseQuantity(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseQuantity(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseQuantityInvalid(t *testing.T) {
+	for _, in := range []string{"", " ", "Gi", "m", "abc", "1Gb", "1.2.3", "5e"} {
+		if got, err := ParseQuantity(in); err == nil {
+			t.Errorf("ParseQuantity(%q) = %v, want an error", in, got)
+		}
+	}
+}
+
+func TestQuantityUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Quantity
+	}{
+		{`"500m"`, "500m"},
+		{`"1Gi"`, "1Gi"},
+		{`2`, "2"},
+		{`0.5`, "0.5"},
+	}
+	for _, tt := range tests {
+		var q Quantity
+		if err := json.Unmarshal([]byte(tt.in), &q); err != nil {
+			t.Errorf("unmarshal %s returned error: %v", tt.in, err)
+			continue
+		}
+		if q != tt.want {
+			t.Errorf("unmarshal %s = %q, want %q", tt.in, q, tt.want)
+		}
+	}
+
+	var q Quantity
+	if err := json.Unmarshal([]byte(`true`), &q); err == nil {
+		t.Errorf("unmarshal true = %q, want an error", q)
+	}
+}
@@ -0,0 +1,290 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PolicyRule is a single rule of a Role or ClusterRole
+type PolicyRule struct {
+	Verbs           []string `json:"verbs,omitempty"`
+	APIGroups       []string `json:"apiGroups,omitempty"`
+	Resources       []string `json:"resources,omitempty"`
+	ResourceNames   []string `json:"resourceNames,omitempty"`
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// RoleRef identifies the role a binding grants
+type RoleRef struct {
+	APIGroup string `json:"apiGroup,omitempty"`
+	Kind     string `json:"kind,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+// Subject is a user, group or service account a binding applies to
+type Subject struct {
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// RBACGrant is one role granted to a subject through a binding
+type RBACGrant struct {
+	Binding   string // Kind/name of the binding
+	Role      string // Kind/name of the role
+	Namespace string // namespace the grant applies to, empty for cluster-wide
+	Rules     []PolicyRule
+	Missing   bool // the referenced role is not in the configuration
+}
+
+// RBACRisk is a dangerous permission held by a subject
+type RBACRisk struct {
+	Check     string
+	Severity  Severity
+	Detail    string
+	Binding   string
+	Role      string
+	Namespace string // namespace the permission applies to, empty for cluster-wide
+}
+
+// SubjectPermissions is the effective RBAC access of one subject
+type SubjectPermissions struct {
+	Kind         string // User, Group or ServiceAccount
+	Name         string
+	Namespace    string // service account namespace
+	ClusterAdmin bool
+	Grants       []RBACGrant
+	Risks        []RBACRisk
+}
+
+// bootstrapLabel marks the default roles and bindings created by the API server
+const bootstrapLabel = "kubernetes.io/bootstrapping"
+
+// GetRBACPermissions resolves RoleBindings and ClusterRoleBindings to their subjects
+// and flags dangerous permissions. The API server's default bootstrap bindings are skipped.
+func GetRBACPermissions(config *ClusterConfig) []SubjectPermissions {
+	roles := make(map[string][]PolicyRule)
+	for _, item := range config.Items {
+		switch item.Kind {
+		case "ClusterRole":
+			var rules []PolicyRule
+			item.DecodeField("rules", &rules)
+			roles["ClusterRole/"+item.Metadata.Name] = rules
+		case "Role":
+			var rules []PolicyRule
+			item.DecodeField("rules", &rules)
+			roles[fmt.Sprintf("Role/%s/%s", item.Metadata.Namespace, item.Metadata.Name)] = rules
+		}
+	}
+
+	subjects := make(map[string]*SubjectPermissions)
+	var order []string
+
+	for _, item := range config.Items {
+		if item.Kind != "RoleBinding" && item.Kind != "ClusterRoleBinding" {
+			continue
+		}
+		if item.Metadata.Labels[bootstrapLabel] == "rbac-defaults" {
+			continue
+		}
+
+		var roleRef RoleRef
+		var bindingSubjects []Subject
+		if !item.DecodeField("roleRef", &roleRef) {
+			continue
+		}
+		item.DecodeField("subjects", &bindingSubjects)
+
+		namespace := ""
+		if item.Kind == "RoleBinding" {
+			namespace = item.Metadata.Namespace
+		}
+
+		roleKey := "ClusterRole/" + roleRef.Name
+		if roleRef.Kind == "Role" {
+			roleKey = fmt.Sprintf("Role/%s/%s", item.Metadata.Namespace, roleRef.Name)
+		}
+		rules, found := roles[roleKey]
+
+		grant := RBACGrant{
+			Binding:   item.Kind + "/" + item.Metadata.Name,
+			Role:      roleRef.Kind + "/" + roleRef.Name,
+			Namespace: namespace,
+			Rules:     rules,
+			Missing:   !found,
+		}
+
+		for _, subject := range bindingSubjects {
+			if subject.Kind == "ServiceAccount" && subject.Namespace == "" {
+				subject.Namespace = item.Metadata.Namespace
+			}
+			key := fmt.Sprintf("%s/%s/%s", subject.Kind, subject.Namespace, subject.Name)
+			perms, ok := subjects[key]
+			if !ok {
+				perms = &SubjectPermissions{
+					Kind:      subject.Kind,
+					Name:      subject.Name,
+					Namespace: subject.Namespace,
+				}
+				subjects[key] = perms
+				order = append(order, key)
+			}
+			perms.Grants = append(perms.Grants, grant)
+			perms.Risks = append(perms.Risks, evaluateGrant(grant, roleRef)...)
+			if grantsClusterAdmin(grant, roleRef) {
+				perms.ClusterAdmin = true
+			}
+		}
+	}
+
+	sort.Strings(order)
+	results := make([]SubjectPermissions, 0, len(order))
+	for _, key := range order {
+		results = append(results, *subjects[key])
+	}
+	return results
+}
+
+// grantsClusterAdmin reports whether a grant gives full control of the cluster
+func grantsClusterAdmin(grant RBACGrant, roleRef RoleRef) bool {
+	if grant.Namespace != "" {
+		return false
+	}
+	if roleRef.Kind == "ClusterRole" && roleRef.Name == "cluster-admin" {
+		return true
+	}
+	for _, rule := range grant.Rules {
+		if containsString(rule.Verbs, "*") && containsString(rule.Resources, "*") && containsString(rule.APIGroups, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateGrant flags the dangerous permissions in a single grant
+func evaluateGrant(grant RBACGrant, roleRef RoleRef) []RBACRisk {
+	var risks []RBACRisk
+	add := func(check string, severity Severity, detail string) {
+		risks = append(risks, RBACRisk{
+			Check:     check,
+			Severity:  severity,
+			Detail:    detail,
+			Binding:   grant.Binding,
+			Role:      grant.Role,
+			Namespace: grant.Namespace,
+		})
+	}
+
+	if grantsClusterAdmin(grant, roleRef) {
+		add("cluster-admin", SeverityCritical, "full control of the cluster")
+		return risks
+	}
+
+	for _, rule := range grant.Rules {
+		if len(rule.Resources) == 0 {
+			continue // non-resource URL rules
+		}
+		if containsString(rule.Verbs, "*") {
+			add("wildcard-verbs", SeverityHigh, fmt.Sprintf("all verbs on %s", strings.Join(rule.Resources, ", ")))
+		}
+		if containsString(rule.Resources, "*") {
+			add("wildcard-resources", SeverityHigh, fmt.Sprintf("%s on all resources", strings.Join(rule.Verbs, ", ")))
+		}
+		// Wildcard verbs are reported above, so only explicit grants are flagged here
+		for _, verb := range []string{"escalate", "bind", "impersonate"} {
+			if containsString(rule.Verbs, verb) {
+				add(verb, SeverityHigh, fmt.Sprintf("%s on %s", verb, strings.Join(rule.Resources, ", ")))
+			}
+		}
+		// A rule limited by resourceNames only reaches the named objects, which is
+		// reported as a narrower "-named" risk one severity lower
+		addScoped := func(check, action, note string) {
+			if len(rule.ResourceNames) > 0 {
+				action += " named " + strings.Join(rule.ResourceNames, ", ")
+			}
+			if note != "" {
+				action += " (" + note + ")"
+			}
+			if len(rule.ResourceNames) == 0 {
+				add(check, SeverityHigh, action)
+				return
+			}
+			add(check+"-named", SeverityMedium, action)
+		}
+		if ruleAllows(rule, "", "pods/exec", "create") {
+			addScoped("pods-exec", "create pods/exec", "run commands in containers")
+		}
+		for _, verb := range []string{"get", "list", "watch"} {
+			if ruleAllows(rule, "", "secrets", verb) {
+				addScoped("secrets-read", verb+" secrets", "")
+				break
+			}
+		}
+		for _, verb := range []string{"get", "create"} {
+			if ruleAllows(rule, "", "nodes/proxy", verb) {
+				addScoped("nodes-proxy", verb+" nodes/proxy", "kubelet API access")
+				break
+			}
+		}
+	}
+
+	return risks
+}
+
+// ruleAllows reports whether a rule permits a verb on a resource in an API group,
+// on every object or only on those listed in its resourceNames
+func ruleAllows(rule PolicyRule, apiGroup, resource, verb string) bool {
+	return matchesRBAC(rule.APIGroups, apiGroup) && matchesRBAC(rule.Resources, resource) && matchesRBAC(rule.Verbs, verb)
+}
+
+// matchesRBAC reports whether a rule's values include a value or the "*" wildcard
+func matchesRBAC(values []string, value string) bool {
+	return containsString(values, "*") || containsString(values, value)
+}
+
+// rbacFindings adapts GetRBACPermissions to findings, one per risky permission
+func rbacFindings(config *ClusterConfig) []Finding {
+	var findings []Finding
+	for _, subject := range GetRBACPermissions(config) {
+		for _, risk := range subject.Risks {
+			scope := "cluster-wide"
+			if risk.Namespace != "" {
+				scope = "in namespace " + risk.Namespace
+			}
+			findings = append(findings, Finding{
+				Severity:  risk.Severity,
+				Namespace: subject.Namespace,
+				Kind:      subject.Kind,
+				Name:      subject.Name,
+				Rule:      fmt.Sprintf("%s %s/%s %s", risk.Check, risk.Namespace, risk.Binding, risk.Role),
+				Details:   fmt.Sprintf("%s: %s %s (%s -> %s)", risk.Check, risk.Detail, scope, risk.Binding, risk.Role),
+			})
+		}
+	}
+	return findings
+}
+
+// FormatRule describes a policy rule as "verbs on resources"
+func FormatRule(rule PolicyRule) string {
+	if len(rule.NonResourceURLs) > 0 {
+		return fmt.Sprintf("%s on %s", strings.Join(rule.Verbs, ","), strings.Join(rule.NonResourceURLs, ","))
+	}
+
+	resources := make([]string, 0, len(rule.Resources))
+	for _, resource := range rule.Resources {
+		for _, group := range rule.APIGroups {
+			if group != "" {
+				resource = resource + "." + group
+				break
+			}
+		}
+		resources = append(resources, resource)
+	}
+
+	text := fmt.Sprintf("%s on %s", strings.Join(rule.Verbs, ","), strings.Join(resources, ","))
+	if len(rule.ResourceNames) > 0 {
+		text += fmt.Sprintf(" (%s)", strings.Join(rule.ResourceNames, ","))
+	}
+	return text
+}
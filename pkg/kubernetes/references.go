@@ -0,0 +1,282 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// implicitObjects are objects Kubernetes creates in every namespace, so references
+// to them are never reported even when the snapshot leaves them out
+var implicitObjects = map[string]bool{
+	"ServiceAccount/default":     true,
+	"ConfigMap/kube-root-ca.crt": true,
+}
+
+// DanglingReference is a reference from one object to another that is not in the configuration
+type DanglingReference struct {
+	Kind       string
+	Name       string
+	Namespace  string
+	TargetKind string
+	TargetName string
+	Via        string // where the reference is made, e.g. "volume data" or "ownerReferences"
+}
+
+// String describes the broken reference, e.g. "ConfigMap app-config (volume config) not found"
+func (r DanglingReference) String() string {
+	return fmt.Sprintf("%s %s (%s) not found", r.TargetKind, r.TargetName, r.Via)
+}
+
+// objectIndex records which objects a configuration contains
+type objectIndex struct {
+	objects map[string]bool // Kind/namespace/name
+	kinds   map[string]bool // kinds with at least one object
+	uids    map[string]bool
+}
+
+// has reports whether the configuration contains an object. References to kinds the
+// snapshot contains none of are treated as resolved, as the snapshot probably omits them.
+func (x objectIndex) has(kind, namespace, name string) bool {
+	if !x.kinds[kind] || implicitObjects[kind+"/"+name] {
+		return true
+	}
+	return x.objects[kind+"/"+namespaceOrDefault(namespace)+"/"+name]
+}
+
+// FindDanglingReferences resolves references between the objects in a configuration
+// and reports those that point to missing objects: pods referencing missing
+// ConfigMaps, Secrets, PersistentVolumeClaims or ServiceAccounts, Ingress backends
+// pointing to missing Services, bindings to missing roles, owner references whose
+// UID is not in the snapshot, and Services whose selector matches no pods.
+func FindDanglingReferences(config *ClusterConfig) []DanglingReference {
+	index := objectIndex{
+		objects: make(map[string]bool),
+		kinds:   make(map[string]bool),
+		uids:    make(map[string]bool),
+	}
+	for _, item := range config.Items {
+		namespace := item.Metadata.Namespace
+		if item.Kind == "ClusterRole" {
+			namespace = ""
+		}
+		index.objects[item.Kind+"/"+namespaceOrDefault(namespace)+"/"+item.Metadata.Name] = true
+		index.kinds[item.Kind] = true
+		if item.Metadata.UID != "" {
+			index.uids[item.Metadata.UID] = true
+		}
+	}
+
+	// Service selectors are only checked when the snapshot contains workloads
+	templates := GetPodTemplates(config)
+
+	var results []DanglingReference
+	for _, item := range config.Items {
+		from := DanglingReference{
+			Kind:      item.Kind,
+			Name:      item.Metadata.Name,
+			Namespace: item.Metadata.Namespace,
+		}
+		report := func(targetKind, targetName, via string) {
+			ref := from
+			ref.TargetKind, ref.TargetName, ref.Via = targetKind, targetName, via
+			results = append(results, ref)
+		}
+
+		// Owner references can only be checked when the snapshot records UIDs
+		if len(index.uids) > 0 {
+			for _, owner := range item.Metadata.OwnerReferences {
+				if owner.UID != "" && !index.uids[owner.UID] {
+					report(owner.Kind, owner.Name, "ownerReferences")
+				}
+			}
+		}
+
+		switch item.Kind {
+		case "RoleBinding", "ClusterRoleBinding":
+			var roleRef RoleRef
+			if !item.DecodeField("roleRef", &roleRef) {
+				continue
+			}
+			namespace := ""
+			if roleRef.Kind == "Role" {
+				namespace = item.Metadata.Namespace
+			}
+			// A snapshot with any roles is assumed to include all of them
+			hasRoles := index.kinds["Role"] || index.kinds["ClusterRole"]
+			if hasRoles && !index.objects[roleRef.Kind+"/"+namespaceOrDefault(namespace)+"/"+roleRef.Name] {
+				report(roleRef.Kind, roleRef.Name, "roleRef")
+			}
+
+		case "Ingress":
+			var spec ingressSpec
+			if decodeInto(item.Spec, &spec) != nil {
+				continue
+			}
+			var services []string
+			for _, backend := range []*ingressBackend{spec.DefaultBackend, spec.Backend} {
+				if backend != nil && backend.serviceName() != "" {
+					services = appendUnique(services, backend.serviceName())
+				}
+			}
+			for _, rule := range spec.Rules {
+				if rule.HTTP == nil {
+					continue
+				}
+				for _, path := range rule.HTTP.Paths {
+					if name := path.Backend.serviceName(); name != "" {
+						services = appendUnique(services, name)
+					}
+				}
+			}
+			for _, name := range services {
+				if !index.has("Service", item.Metadata.Namespace, name) {
+					report("Service", name, "backend")
+				}
+			}
+
+		case "Service":
+			var spec ServiceSpec
+			if len(templates) == 0 || decodeInto(item.Spec, &spec) != nil || len(spec.Selector) == 0 {
+				continue
+			}
+			if !selectsAnyPod(templates, item.Metadata.Namespace, spec.Selector) {
+				report("Pod", formatSelector(spec.Selector), "selector")
+			}
+		}
+
+		template, ok := GetPodTemplate(item)
+		if !ok || template.IsManaged() {
+			continue
+		}
+		for _, ref := range podReferences(template.Spec) {
+			if !index.has(ref.TargetKind, item.Metadata.Namespace, ref.TargetName) {
+				report(ref.TargetKind, ref.TargetName, ref.Via)
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return results
+}
+
+// podReferences returns the required ConfigMaps, Secrets, PersistentVolumeClaims
+// and ServiceAccount a pod spec refers to. Optional references are left out.
+func podReferences(spec PodSpec) []DanglingReference {
+	var refs []DanglingReference
+	add := func(kind, name, via string) {
+		if name != "" {
+			refs = append(refs, DanglingReference{TargetKind: kind, TargetName: name, Via: via})
+		}
+	}
+	addLocal := func(kind string, ref *LocalObjectRef, via string) {
+		if ref != nil && !ref.Optional {
+			add(kind, ref.Name, via)
+		}
+	}
+
+	add("ServiceAccount", spec.ServiceAccountName, "serviceAccountName")
+	for _, secret := range spec.ImagePullSecrets {
+		add("Secret", secret.Name, "imagePullSecrets")
+	}
+
+	for _, volume := range spec.Volumes {
+		via := "volume " + volume.Name
+		addLocal("ConfigMap", volume.ConfigMap, via)
+		if volume.Secret != nil && !volume.Secret.Optional {
+			add("Secret", volume.Secret.SecretName, via)
+		}
+		if volume.PersistentVolumeClaim != nil {
+			add("PersistentVolumeClaim", volume.PersistentVolumeClaim.ClaimName, via)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				addLocal("ConfigMap", source.ConfigMap, via)
+				addLocal("Secret", source.Secret, via)
+			}
+		}
+	}
+
+	for _, container := range spec.PodContainers() {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			via := fmt.Sprintf("container %s env %s", container.Name, env.Name)
+			addLocal("ConfigMap", env.ValueFrom.ConfigMapKeyRef, via)
+			addLocal("Secret", env.ValueFrom.SecretKeyRef, via)
+		}
+		for _, from := range container.EnvFrom {
+			via := fmt.Sprintf("container %s envFrom", container.Name)
+			addLocal("ConfigMap", from.ConfigMapRef, via)
+			addLocal("Secret", from.SecretRef, via)
+		}
+	}
+
+	// A pod may refer to the same object several times; report it once
+	seen := make(map[string]bool)
+	var unique []DanglingReference
+	for _, ref := range refs {
+		key := ref.TargetKind + "/" + ref.TargetName
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, ref)
+		}
+	}
+	return unique
+}
+
+// selectsAnyPod reports whether a Service selector matches the pods of any workload
+// in the namespace
+func selectsAnyPod(templates []PodTemplate, namespace string, selector map[string]string) bool {
+	for _, template := range templates {
+		if namespaceOrDefault(template.Namespace) == namespaceOrDefault(namespace) &&
+			MatchesSelector(selector, template.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatSelector formats an equality-based selector as key=value pairs
+func formatSelector(selector map[string]string) string {
+	var pairs []string
+	for key, value := range selector {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// referenceFindings adapts FindDanglingReferences to findings. Owner references to
+// missing owners are low severity, as garbage collection removes such objects.
+func referenceFindings(config *ClusterConfig) []Finding {
+	var findings []Finding
+	for _, ref := range FindDanglingReferences(config) {
+		finding := Finding{
+			Namespace: ref.Namespace,
+			Kind:      ref.Kind,
+			Name:      ref.Name,
+			Rule:      fmt.Sprintf("%s %s/%s", ref.Via, ref.TargetKind, ref.TargetName),
+			Details:   ref.String(),
+		}
+		switch ref.Via {
+		case "ownerReferences":
+			finding.Severity = SeverityLow
+			finding.Details = fmt.Sprintf("Owner %s %s not found", ref.TargetKind, ref.TargetName)
+		case "selector":
+			finding.Details = "Selector " + ref.TargetName + " matches no pods"
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
@@ -0,0 +1,303 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Reliability issues
+const (
+	ReliabilitySingleReplica = "single-replica-no-pdb" // one replica and no PodDisruptionBudget
+	ReliabilityNoReadiness   = "no-readiness-probe"
+	ReliabilityNoLiveness    = "no-liveness-probe"
+	ReliabilityNoSpread      = "no-spread" // several replicas without spread constraints or anti-affinity
+	ReliabilityBlockingPDB   = "pdb-blocks-evictions"
+)
+
+// ReliabilityWorkload is a workload with reliability issues
+type ReliabilityWorkload struct {
+	Kind             string
+	Name             string
+	Namespace        string
+	Replicas         int // 0 for kinds without replicas, such as DaemonSets
+	PDBs             []string
+	Issues           []string
+	NoReadinessProbe []string // containers without a readiness probe
+	NoLivenessProbe  []string // containers without a liveness probe
+}
+
+// BlockingPDB is a PodDisruptionBudget that allows no voluntary evictions, which
+// stalls node drains and cluster upgrades
+type BlockingPDB struct {
+	Name      string
+	Namespace string
+	Reason    string
+	Workloads []string // Kind/Name of the workloads it selects
+}
+
+// ReliabilityResult is the reliability analysis of a configuration
+type ReliabilityResult struct {
+	Workloads    []ReliabilityWorkload
+	BlockingPDBs []BlockingPDB
+}
+
+// pdbSpec is the part of a PodDisruptionBudget spec used for reliability analysis.
+// minAvailable and maxUnavailable are either a number or a percentage.
+type pdbSpec struct {
+	MinAvailable   interface{}    `json:"minAvailable,omitempty"`
+	MaxUnavailable interface{}    `json:"maxUnavailable,omitempty"`
+	Selector       *LabelSelector `json:"selector,omitempty"`
+}
+
+// replicaSpec is the part of a Deployment or StatefulSet spec giving its replicas
+type replicaSpec struct {
+	Replicas *int `json:"replicas,omitempty"`
+}
+
+// reliabilityKinds are the workload kinds checked, and whether they have replicas
+var reliabilityKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   false,
+}
+
+// AnalyzeReliability flags Deployments and StatefulSets with one replica and no
+// PodDisruptionBudget, PDBs that block every eviction, containers without readiness
+// or liveness probes and multi-replica workloads without topologySpreadConstraints
+// or pod anti-affinity
+func AnalyzeReliability(config *ClusterConfig) ReliabilityResult {
+	type pdb struct {
+		item      Item
+		spec      pdbSpec
+		workloads []string
+		replicas  int
+	}
+	var pdbs []*pdb
+	for _, item := range config.Items {
+		if item.Kind != "PodDisruptionBudget" {
+			continue
+		}
+		p := &pdb{item: item}
+		decodeInto(item.Spec, &p.spec)
+		pdbs = append(pdbs, p)
+	}
+
+	var result ReliabilityResult
+	for _, item := range config.Items {
+		hasReplicas, ok := reliabilityKinds[item.Kind]
+		if !ok {
+			continue
+		}
+		template, ok := GetPodTemplate(item)
+		if !ok || template.IsManaged() {
+			continue
+		}
+
+		workload := ReliabilityWorkload{
+			Kind:      template.Kind,
+			Name:      template.Name,
+			Namespace: namespaceOrDefault(template.Namespace),
+		}
+		if hasReplicas {
+			var spec replicaSpec
+			decodeInto(item.Spec, &spec)
+			workload.Replicas = 1
+			if spec.Replicas != nil {
+				workload.Replicas = *spec.Replicas
+			}
+		}
+
+		for _, p := range pdbs {
+			if namespaceOrDefault(p.item.Metadata.Namespace) != workload.Namespace {
+				continue
+			}
+			// In policy/v1 an empty selector selects every pod in the namespace and a
+			// missing one selects none
+			if p.spec.Selector == nil || !p.spec.Selector.Matches(template.Labels) {
+				continue
+			}
+			workload.PDBs = append(workload.PDBs, p.item.Metadata.Name)
+			p.workloads = append(p.workloads, workload.Kind+"/"+workload.Name)
+			p.replicas += workload.Replicas
+		}
+
+		if hasReplicas && workload.Replicas == 1 && len(workload.PDBs) == 0 {
+			workload.Issues = append(workload.Issues, ReliabilitySingleReplica)
+		}
+		for _, container := range template.Spec.Containers {
+			if container.ReadinessProbe == nil {
+				workload.NoReadinessProbe = append(workload.NoReadinessProbe, container.Name)
+			}
+			if container.LivenessProbe == nil {
+				workload.NoLivenessProbe = append(workload.NoLivenessProbe, container.Name)
+			}
+		}
+		if len(workload.NoReadinessProbe) > 0 {
+			workload.Issues = append(workload.Issues, ReliabilityNoReadiness)
+		}
+		if len(workload.NoLivenessProbe) > 0 {
+			workload.Issues = append(workload.Issues, ReliabilityNoLiveness)
+		}
+		if workload.Replicas > 1 && !spreadsReplicas(template.Spec) {
+			workload.Issues = append(workload.Issues, ReliabilityNoSpread)
+		}
+
+		if len(workload.Issues) > 0 {
+			result.Workloads = append(result.Workloads, workload)
+		}
+	}
+
+	for _, p := range pdbs {
+		// A PDB without a selector protects no pods, so it cannot block evictions
+		if p.spec.Selector == nil {
+			continue
+		}
+		if reason := pdbBlocksEvictions(p.spec, p.replicas, len(p.workloads) > 0); reason != "" {
+			result.BlockingPDBs = append(result.BlockingPDBs, BlockingPDB{
+				Name:      p.item.Metadata.Name,
+				Namespace: namespaceOrDefault(p.item.Metadata.Namespace),
+				Reason:    reason,
+				Workloads: p.workloads,
+			})
+		}
+	}
+
+	sort.Slice(result.Workloads, func(i, j int) bool {
+		a, b := result.Workloads[i], result.Workloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	sort.Slice(result.BlockingPDBs, func(i, j int) bool {
+		a, b := result.BlockingPDBs[i], result.BlockingPDBs[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return result
+}
+
+// spreadsReplicas reports whether a pod spec spreads its replicas with topology
+// spread constraints or pod anti-affinity
+func spreadsReplicas(spec PodSpec) bool {
+	if len(spec.TopologySpreadConstraints) > 0 {
+		return true
+	}
+	anti := spec.Affinity
+	return anti != nil && anti.PodAntiAffinity != nil &&
+		(len(anti.PodAntiAffinity.Required) > 0 || len(anti.PodAntiAffinity.Preferred) > 0)
+}
+
+// pdbBlocksEvictions returns why a PodDisruptionBudget allows no voluntary evictions,
+// or "" if it allows some. replicas is the total of the workloads it selects; it is
+// only compared against minAvailable when the PDB selects a known workload.
+// Percentages of maxUnavailable round up, so only 0 blocks evictions.
+func pdbBlocksEvictions(spec pdbSpec, replicas int, selectsWorkloads bool) string {
+	if spec.MaxUnavailable != nil {
+		value, percent, ok := intOrPercent(spec.MaxUnavailable)
+		if !ok {
+			return ""
+		}
+		if value == 0 {
+			return "maxUnavailable is " + formatIntOrPercent(value, percent)
+		}
+		return ""
+	}
+
+	if spec.MinAvailable == nil {
+		return ""
+	}
+	value, percent, ok := intOrPercent(spec.MinAvailable)
+	if !ok {
+		return ""
+	}
+	if percent && value >= 100 {
+		return "minAvailable is " + formatIntOrPercent(value, percent)
+	}
+	if !selectsWorkloads || replicas == 0 {
+		return ""
+	}
+	// Percentages are rounded up, as the disruption controller does
+	required := value
+	if percent {
+		required = (value*replicas + 99) / 100
+	}
+	if required >= replicas {
+		return fmt.Sprintf("minAvailable %s with %d replicas", formatIntOrPercent(value, percent), replicas)
+	}
+	return ""
+}
+
+// intOrPercent decodes a Kubernetes IntOrString value such as 1 or "50%"
+func intOrPercent(value interface{}) (int, bool, bool) {
+	switch v := value.(type) {
+	case float64:
+		return int(v), false, true
+	case string:
+		if strings.HasSuffix(v, "%") {
+			n, err := strconv.Atoi(strings.TrimSuffix(v, "%"))
+			return n, true, err == nil
+		}
+		n, err := strconv.Atoi(v)
+		return n, false, err == nil
+	}
+	return 0, false, false
+}
+
+// formatIntOrPercent formats a value decoded by intOrPercent
+func formatIntOrPercent(value int, percent bool) string {
+	if percent {
+		return fmt.Sprintf("%d%%", value)
+	}
+	return strconv.Itoa(value)
+}
+
+// reliabilityFindings adapts AnalyzeReliability to findings. Single replicas without
+// a PDB and PDBs that block evictions are medium severity.
+func reliabilityFindings(config *ClusterConfig) []Finding {
+	result := AnalyzeReliability(config)
+
+	var findings []Finding
+	for _, w := range result.Workloads {
+		for _, issue := range w.Issues {
+			finding := Finding{
+				Namespace: w.Namespace,
+				Kind:      w.Kind,
+				Name:      w.Name,
+				Rule:      issue,
+			}
+			switch issue {
+			case ReliabilitySingleReplica:
+				finding.Severity = SeverityMedium
+				finding.Details = "1 replica and no PodDisruptionBudget"
+			case ReliabilityNoReadiness:
+				finding.Details = "No readiness probe: " + strings.Join(w.NoReadinessProbe, ", ")
+			case ReliabilityNoLiveness:
+				finding.Details = "No liveness probe: " + strings.Join(w.NoLivenessProbe, ", ")
+			case ReliabilityNoSpread:
+				finding.Details = fmt.Sprintf("%d replicas without topologySpreadConstraints or pod anti-affinity", w.Replicas)
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	for _, p := range result.BlockingPDBs {
+		findings = append(findings, Finding{
+			Severity:  SeverityMedium,
+			Namespace: p.Namespace,
+			Kind:      "PodDisruptionBudget",
+			Name:      p.Name,
+			Rule:      "blocks-evictions",
+			Details:   "Blocks all evictions: " + p.Reason,
+		})
+	}
+	return findings
+}
@@ -0,0 +1,287 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Pod QoS classes
+const (
+	QoSGuaranteed = "Guaranteed"
+	QoSBurstable  = "Burstable"
+	QoSBestEffort = "BestEffort"
+)
+
+// LimitRatioThreshold is how many times its request a limit may be before it is flagged
+const LimitRatioThreshold = 4.0
+
+// ContainerResources is the compute resource configuration of one container
+type ContainerResources struct {
+	Name          string
+	CPURequest    string
+	CPULimit      string
+	MemoryRequest string
+	MemoryLimit   string
+	Missing       []string // e.g. "cpu request", "memory limit"
+	HighLimits    []string // limits far above their request, e.g. "cpu limit 8x request"
+}
+
+// ResourceWorkload is a workload's QoS class and container resources
+type ResourceWorkload struct {
+	Kind       string
+	Name       string
+	Namespace  string
+	QoSClass   string
+	Containers []ContainerResources
+}
+
+// ResourceNamespace aggregates resource hygiene for a namespace
+type ResourceNamespace struct {
+	Namespace        string
+	Workloads        int
+	Containers       int
+	MissingRequests  int // containers without a CPU or memory request
+	MissingLimits    int // containers without a CPU or memory limit
+	HighLimits       int // containers with a limit far above its request
+	Guaranteed       int
+	Burstable        int
+	BestEffort       int
+	HasLimitRange    bool
+	HasResourceQuota bool
+}
+
+// ResourceResult is the resource requests/limits and QoS analysis of a configuration
+type ResourceResult struct {
+	Namespaces []ResourceNamespace
+	Workloads  []ResourceWorkload
+}
+
+// AnalyzeResources reports missing CPU and memory requests and limits, limits far
+// above requests and each workload's QoS class, aggregated per namespace along with
+// whether the namespace has a LimitRange or ResourceQuota
+func AnalyzeResources(config *ClusterConfig) ResourceResult {
+	namespaces := make(map[string]*ResourceNamespace)
+	namespaceFor := func(name string) *ResourceNamespace {
+		ns, ok := namespaces[name]
+		if !ok {
+			ns = &ResourceNamespace{Namespace: name}
+			namespaces[name] = ns
+		}
+		return ns
+	}
+
+	var result ResourceResult
+	for _, template := range GetPodTemplates(config) {
+		if template.IsManaged() {
+			continue // covered by the controller that created it
+		}
+
+		namespace := namespaceOrDefault(template.Namespace)
+		ns := namespaceFor(namespace)
+		ns.Workloads++
+
+		workload := ResourceWorkload{
+			Kind:      template.Kind,
+			Name:      template.Name,
+			Namespace: namespace,
+			QoSClass:  QoSClass(template.Spec),
+		}
+		switch workload.QoSClass {
+		case QoSGuaranteed:
+			ns.Guaranteed++
+		case QoSBurstable:
+			ns.Burstable++
+		default:
+			ns.BestEffort++
+		}
+
+		for _, container := range template.Spec.PodContainers() {
+			resources := containerResources(container)
+			workload.Containers = append(workload.Containers, resources)
+
+			ns.Containers++
+			if containsString(resources.Missing, "cpu request") || containsString(resources.Missing, "memory request") {
+				ns.MissingRequests++
+			}
+			if containsString(resources.Missing, "cpu limit") || containsString(resources.Missing, "memory limit") {
+				ns.MissingLimits++
+			}
+			if len(resources.HighLimits) > 0 {
+				ns.HighLimits++
+			}
+		}
+
+		result.Workloads = append(result.Workloads, workload)
+	}
+
+	for _, item := range config.Items {
+		switch item.Kind {
+		case "LimitRange":
+			if ns, ok := namespaces[namespaceOrDefault(item.Metadata.Namespace)]; ok {
+				ns.HasLimitRange = true
+			}
+		case "ResourceQuota":
+			if ns, ok := namespaces[namespaceOrDefault(item.Metadata.Namespace)]; ok {
+				ns.HasResourceQuota = true
+			}
+		}
+	}
+
+	for _, ns := range namespaces {
+		result.Namespaces = append(result.Namespaces, *ns)
+	}
+	sort.Slice(result.Namespaces, func(i, j int) bool {
+		return result.Namespaces[i].Namespace < result.Namespaces[j].Namespace
+	})
+	sort.Slice(result.Workloads, func(i, j int) bool {
+		a, b := result.Workloads[i], result.Workloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	return result
+}
+
+// containerResources summarises a container's requests and limits
+func containerResources(container Container) ContainerResources {
+	requests, limits := container.Resources.Requests, container.Resources.Limits
+	resources := ContainerResources{
+		Name:          container.Name,
+		CPURequest:    string(requests["cpu"]),
+		CPULimit:      string(limits["cpu"]),
+		MemoryRequest: string(requests["memory"]),
+		MemoryLimit:   string(limits["memory"]),
+	}
+
+	for _, resource := range []string{"cpu", "memory"} {
+		request, hasRequest := requests[resource]
+		limit, hasLimit := limits[resource]
+
+		// A limit without a request sets the request to the limit
+		if !hasRequest && !hasLimit {
+			resources.Missing = append(resources.Missing, resource+" request")
+		}
+		if !hasLimit {
+			resources.Missing = append(resources.Missing, resource+" limit")
+		}
+
+		if hasRequest && hasLimit {
+			requestValue, err1 := request.Value()
+			limitValue, err2 := limit.Value()
+			if err1 == nil && err2 == nil && requestValue > 0 && limitValue/requestValue > LimitRatioThreshold {
+				resources.HighLimits = append(resources.HighLimits,
+					fmt.Sprintf("%s limit %.0fx request", resource, limitValue/requestValue))
+			}
+		}
+	}
+
+	return resources
+}
+
+// QoSClass returns the QoS class Kubernetes assigns to pods with this spec
+func QoSClass(spec PodSpec) string {
+	guaranteed := true
+	anySet := false
+
+	for _, container := range spec.PodContainers() {
+		requests, limits := container.Resources.Requests, container.Resources.Limits
+		for _, resource := range []string{"cpu", "memory"} {
+			request, hasRequest := requests[resource]
+			limit, hasLimit := limits[resource]
+			if hasRequest || hasLimit {
+				anySet = true
+			}
+			if !hasLimit {
+				guaranteed = false
+				continue
+			}
+			if hasRequest && !quantitiesEqual(request, limit) {
+				guaranteed = false
+			}
+		}
+	}
+
+	switch {
+	case !anySet:
+		return QoSBestEffort
+	case guaranteed:
+		return QoSGuaranteed
+	default:
+		return QoSBurstable
+	}
+}
+
+// quantitiesEqual compares two quantities by value, falling back to their text
+func quantitiesEqual(a, b Quantity) bool {
+	av, err1 := a.Value()
+	bv, err2 := b.Value()
+	if err1 != nil || err2 != nil {
+		return a == b
+	}
+	return av == bv
+}
+
+// resourceFindings adapts AnalyzeResources to findings
+func resourceFindings(config *ClusterConfig) []Finding {
+	result := AnalyzeResources(config)
+
+	var findings []Finding
+	for _, ns := range result.Namespaces {
+		var missing []string
+		if !ns.HasLimitRange {
+			missing = append(missing, "LimitRange")
+		}
+		if !ns.HasResourceQuota {
+			missing = append(missing, "ResourceQuota")
+		}
+		if len(missing) > 0 {
+			findings = append(findings, Finding{
+				Namespace: ns.Namespace,
+				Kind:      "Namespace",
+				Name:      ns.Namespace,
+				Rule:      "limits",
+				Details:   "No " + strings.Join(missing, " or "),
+			})
+		}
+	}
+
+	for _, w := range result.Workloads {
+		if w.QoSClass == QoSBestEffort {
+			findings = append(findings, Finding{
+				Severity:  SeverityMedium,
+				Namespace: w.Namespace,
+				Kind:      w.Kind,
+				Name:      w.Name,
+				Rule:      "best-effort",
+				Details:   "QoS BestEffort: no container sets requests or limits",
+			})
+			continue
+		}
+		for _, c := range w.Containers {
+			var issues []string
+			if len(c.Missing) > 0 {
+				issues = []string{"missing " + strings.Join(c.Missing, ", ")}
+			}
+			issues = append(issues, c.HighLimits...)
+			if len(issues) == 0 {
+				continue
+			}
+			findings = append(findings, Finding{
+				Namespace: w.Namespace,
+				Kind:      w.Kind,
+				Name:      w.Name,
+				Container: c.Name,
+				Rule:      "requests-limits",
+				Details:   fmt.Sprintf("QoS %s: %s", w.QoSClass, strings.Join(issues, "; ")),
+			})
+		}
+	}
+
+	return findings
+}
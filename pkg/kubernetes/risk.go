@@ -0,0 +1,260 @@
+package kubernetes
+
+import (
+	"sort"
+	"strings"
+)
+
+// Weight is how much a finding of a severity adds to a risk score. The steps are
+// steep so that one privileged container outweighs a handful of missing probes.
+func (s Severity) Weight() int {
+	switch s {
+	case SeverityCritical:
+		return 10
+	case SeverityHigh:
+		return 5
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// RiskScore is the weighted sum of a set of findings and their number per severity.
+// Findings counts the conditions scored, so it can be lower than the findings reported.
+type RiskScore struct {
+	Score    int `json:"score"`
+	Findings int `json:"findings"`
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Info     int `json:"info"`
+}
+
+// add counts a finding towards the score
+func (r *RiskScore) add(severity Severity) {
+	r.Score += severity.Weight()
+	r.Findings++
+	switch severity {
+	case SeverityCritical:
+		r.Critical++
+	case SeverityHigh:
+		r.High++
+	case SeverityMedium:
+		r.Medium++
+	case SeverityLow:
+		r.Low++
+	default:
+		r.Info++
+	}
+}
+
+// WorkloadRisk is the risk score of one object findings were reported on, usually a
+// workload but also namespaces, RBAC subjects and cluster-scoped objects
+type WorkloadRisk struct {
+	Namespace string `json:"namespace,omitempty"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	RiskScore
+}
+
+// NamespaceRisk is the risk score of the findings in a namespace
+type NamespaceRisk struct {
+	Namespace string `json:"namespace"`
+	RiskScore
+}
+
+// RiskReport rolls the findings of security analyzers up into scores per workload,
+// namespace and for the whole cluster, highest first. Findings of hygiene analyzers
+// are scored separately for the whole cluster only.
+type RiskReport struct {
+	Cluster    RiskScore       `json:"cluster"`
+	Hygiene    RiskScore       `json:"hygiene"`
+	Namespaces []NamespaceRisk `json:"namespaces,omitempty"`
+	Workloads  []WorkloadRisk  `json:"workloads,omitempty"`
+}
+
+// hygieneAnalyzers report operational and housekeeping issues rather than security
+// risk, so their findings do not count towards the risk score
+var hygieneAnalyzers = []string{
+	CheckImages, CheckResources, CheckDeprecatedAPIs, CheckReliability, CheckReferences,
+}
+
+// IsHygieneAnalyzer reports whether an analyzer's findings count towards the hygiene
+// score rather than the risk score
+func IsHygieneAnalyzer(id string) bool {
+	return containsString(hygieneAnalyzers, id)
+}
+
+// clusterScopedKinds are the kinds whose findings count towards the cluster score only
+var clusterScopedKinds = []string{
+	"ClusterRole", "ClusterRoleBinding", "User", "Group", "Node", "PersistentVolume",
+	"StorageClass", "CustomResourceDefinition", "MutatingWebhookConfiguration",
+	"ValidatingWebhookConfiguration", "PriorityClass", "IngressClass", "APIService",
+}
+
+// pssOverlaps maps the baseline checks that restate what a dedicated analyzer
+// reports, with its own severity, to that analyzer
+var pssOverlaps = map[string]string{
+	"Privileged Containers": CheckPrivileged,
+	"Capabilities":          CheckCapabilities,
+	"Host Namespaces":       CheckHostNamespaces,
+	"Host Ports":            CheckHostNamespaces,
+	"HostPath Volumes":      CheckHostPath,
+}
+
+// pssCheck returns the profile and check of a PSS finding from its details
+func pssCheck(f Finding) (level, check string) {
+	level, rest, _ := strings.Cut(f.Details, ": ")
+	check, _, _ = strings.Cut(rest, " (")
+	return level, check
+}
+
+// scoredFindings picks the findings of security analyzers that count towards the
+// risk score, so each underlying condition is scored once. A baseline violation a
+// dedicated analyzer also reported for the same container is left to that
+// analyzer, and the remaining PSS violations of a workload count once per profile
+// at their highest severity.
+func scoredFindings(findings map[string][]Finding) []Finding {
+	condition := func(id string, f Finding) string {
+		return id + "|" + f.Namespace + "/" + f.Kind + "/" + f.Name + "|" + f.Container
+	}
+	reported := make(map[string]bool)
+	for id := range findings {
+		if id == CheckPSS || IsHygieneAnalyzer(id) {
+			continue
+		}
+		for _, f := range findings[id] {
+			reported[condition(id, f)] = true
+		}
+	}
+
+	var scored []Finding
+	profiles := make(map[string]int)
+	for id, list := range findings {
+		if IsHygieneAnalyzer(id) {
+			continue
+		}
+		if id != CheckPSS {
+			scored = append(scored, list...)
+			continue
+		}
+		for _, f := range list {
+			level, check := pssCheck(f)
+			if analyzer, ok := pssOverlaps[check]; ok && level == string(PSSBaseline) && reported[condition(analyzer, f)] {
+				continue
+			}
+			key := f.Namespace + "/" + f.Kind + "/" + f.Name + "|" + level
+			if i, ok := profiles[key]; ok {
+				if f.Severity.Rank() > scored[i].Severity.Rank() {
+					scored[i].Severity = f.Severity
+				}
+				continue
+			}
+			profiles[key] = len(scored)
+			scored = append(scored, Finding{
+				AnalyzerID: f.AnalyzerID,
+				Severity:   f.Severity,
+				Namespace:  f.Namespace,
+				Kind:       f.Kind,
+				Name:       f.Name,
+			})
+		}
+	}
+	return scored
+}
+
+// ScoreFindings computes the risk report of findings keyed by analyzer ID. Findings
+// on a Namespace count towards that namespace; findings without a namespace on
+// namespaced kinds towards "default". Findings of hygiene analyzers only count
+// towards the hygiene score. Findings restating the same condition are scored
+// once, as scoredFindings describes.
+func ScoreFindings(findings map[string][]Finding) RiskReport {
+	var report RiskReport
+	namespaces := make(map[string]*NamespaceRisk)
+	workloads := make(map[string]*WorkloadRisk)
+
+	for id, list := range findings {
+		if !IsHygieneAnalyzer(id) {
+			continue
+		}
+		for _, f := range list {
+			report.Hygiene.add(f.Severity)
+		}
+	}
+
+	for _, f := range scoredFindings(findings) {
+		report.Cluster.add(f.Severity)
+
+		namespace := f.Namespace
+		switch {
+		case f.Kind == "Namespace":
+			namespace = f.Name
+		case containsString(clusterScopedKinds, f.Kind):
+			namespace = ""
+		default:
+			namespace = namespaceOrDefault(namespace)
+		}
+		if namespace != "" {
+			ns, ok := namespaces[namespace]
+			if !ok {
+				ns = &NamespaceRisk{Namespace: namespace}
+				namespaces[namespace] = ns
+			}
+			ns.add(f.Severity)
+		}
+
+		if f.Kind == "" || f.Name == "" {
+			continue
+		}
+		key := namespace + "/" + f.Kind + "/" + f.Name
+		workload, ok := workloads[key]
+		if !ok {
+			workload = &WorkloadRisk{Namespace: namespace, Kind: f.Kind, Name: f.Name}
+			workloads[key] = workload
+		}
+		workload.add(f.Severity)
+	}
+
+	for _, ns := range namespaces {
+		report.Namespaces = append(report.Namespaces, *ns)
+	}
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		a, b := report.Namespaces[i], report.Namespaces[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Namespace < b.Namespace
+	})
+
+	for _, w := range workloads {
+		report.Workloads = append(report.Workloads, *w)
+	}
+	sort.Slice(report.Workloads, func(i, j int) bool {
+		a, b := report.Workloads[i], report.Workloads[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+// NamespaceScore returns the risk score of a namespace in the report
+func (r RiskReport) NamespaceScore(namespace string) RiskScore {
+	for _, ns := range r.Namespaces {
+		if ns.Namespace == namespace {
+			return ns.RiskScore
+		}
+	}
+	return RiskScore{}
+}
@@ -0,0 +1,312 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Ways a workload can be scheduled onto control plane nodes
+const (
+	SchedulingControlPlaneToleration = "control-plane-toleration" // tolerates the control plane taint
+	SchedulingTolerateAll            = "tolerates-all-taints"     // operator: Exists with no key
+	SchedulingNodeName               = "node-name"                // pinned with nodeName, bypassing the scheduler
+	SchedulingControlPlaneSelector   = "control-plane-selector"   // nodeSelector or node affinity for control plane labels
+)
+
+// Whether the Node objects in the snapshot confirm a scheduling escape
+const (
+	SchedulingConfirmed  = "confirmed"   // the workload can run on a control plane node in the snapshot
+	SchedulingNotMatched = "not-matched" // no control plane node in the snapshot admits the workload
+	SchedulingUnverified = "unverified"  // the snapshot has no control plane nodes to check against
+)
+
+// controlPlaneRoles are the node labels and taint keys that mark control plane nodes
+var controlPlaneRoles = []string{"node-role.kubernetes.io/control-plane", "node-role.kubernetes.io/master"}
+
+// SchedulingEscape is a workload that can be scheduled onto control plane nodes, where
+// a privileged container or hostPath mount reaches etcd data and control plane keys
+type SchedulingEscape struct {
+	Kind              string
+	Name              string
+	Namespace         string
+	Issues            []string
+	Evidence          []string // the setting behind each issue
+	Confirmation      string
+	ControlPlaneNodes []string // control plane nodes in the snapshot the workload can run on
+	HostEscapes       []string // settings that let it take over the node, see hostEscapes
+}
+
+// schedulingNode is a Node and what decides which pods it admits
+type schedulingNode struct {
+	name          string
+	labels        map[string]string
+	taints        []Taint
+	unschedulable bool
+}
+
+// isControlPlane reports whether a node carries a control plane role label or taint
+func (n schedulingNode) isControlPlane() bool {
+	for _, role := range controlPlaneRoles {
+		if _, ok := n.labels[role]; ok {
+			return true
+		}
+		for _, taint := range n.taints {
+			if taint.Key == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// admits reports whether a pod can run on the node. A pod pinned with nodeName
+// bypasses the scheduler, so only NoExecute taints, which evict it, keep it off.
+func (n schedulingNode) admits(spec PodSpec) bool {
+	if spec.NodeName != "" {
+		if spec.NodeName != n.name {
+			return false
+		}
+		for _, taint := range n.taints {
+			if taint.Effect == "NoExecute" && !tolerates(spec.Tolerations, taint) {
+				return false
+			}
+		}
+		return true
+	}
+	if nodeRejects(spec, n.unschedulable, n.labels, n.taints) != "" {
+		return false
+	}
+	return matchesRequiredNodeAffinity(spec, n.name, n.labels)
+}
+
+// matchesRequiredNodeAffinity reports whether a node satisfies any of the pod's
+// required node affinity terms, or true when it has none
+func matchesRequiredNodeAffinity(spec PodSpec, name string, labels map[string]string) bool {
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity.NodeAffinity.Required == nil {
+		return true
+	}
+	terms := spec.Affinity.NodeAffinity.Required.NodeSelectorTerms
+	if len(terms) == 0 {
+		return true
+	}
+	for _, term := range terms {
+		matches := true
+		for _, req := range term.MatchExpressions {
+			value, ok := labels[req.Key]
+			matches = matches && matchesNodeRequirement(req, value, ok)
+		}
+		for _, req := range term.MatchFields {
+			matches = matches && req.Key == "metadata.name" && matchesNodeRequirement(req, name, true)
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNodeRequirement evaluates a node selector requirement against a value,
+// where ok reports whether the node has the key at all
+func matchesNodeRequirement(req NodeSelectorRequirement, value string, ok bool) bool {
+	switch req.Operator {
+	case "In":
+		return ok && containsString(req.Values, value)
+	case "NotIn":
+		return !ok || !containsString(req.Values, value)
+	case "Exists":
+		return ok
+	case "DoesNotExist":
+		return !ok
+	case "Gt", "Lt":
+		if !ok || len(req.Values) != 1 {
+			return false
+		}
+		have, err := strconv.Atoi(value)
+		if err != nil {
+			return false
+		}
+		want, err := strconv.Atoi(req.Values[0])
+		if err != nil {
+			return false
+		}
+		if req.Operator == "Gt" {
+			return have > want
+		}
+		return have < want
+	}
+	return false
+}
+
+// targetsControlPlane reports whether a requirement selects nodes with a control plane role
+func targetsControlPlane(req NodeSelectorRequirement) bool {
+	return containsString(controlPlaneRoles, req.Key) && (req.Operator == "In" || req.Operator == "Exists")
+}
+
+// schedulingIssues lists how a pod spec can reach control plane nodes
+func schedulingIssues(template PodTemplate, controlPlane map[string]bool) (issues, evidence []string) {
+	spec := template.Spec
+	add := func(issue, detail string) {
+		issues = append(issues, issue)
+		evidence = append(evidence, detail)
+	}
+
+	for _, t := range spec.Tolerations {
+		switch {
+		case t.Key == "" && t.Operator == "Exists":
+			detail := "toleration operator: Exists with no key"
+			if t.Effect != "" {
+				detail += " (" + t.Effect + ")"
+			}
+			add(SchedulingTolerateAll, detail)
+		case containsString(controlPlaneRoles, t.Key):
+			add(SchedulingControlPlaneToleration, "toleration for "+t.Key)
+		}
+	}
+
+	// A controller's template pins every replica; a Pod's nodeName is usually set by
+	// the scheduler, so it is only reported when it names a control plane node
+	if spec.NodeName != "" && (template.Kind != "Pod" || controlPlane[spec.NodeName]) {
+		add(SchedulingNodeName, "nodeName "+spec.NodeName)
+	}
+
+	for _, key := range controlPlaneRoles {
+		if value, ok := spec.NodeSelector[key]; ok {
+			add(SchedulingControlPlaneSelector, fmt.Sprintf("nodeSelector %s=%s", key, value))
+		}
+	}
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil {
+		affinity := spec.Affinity.NodeAffinity
+		if affinity.Required != nil {
+			for _, term := range affinity.Required.NodeSelectorTerms {
+				for _, req := range term.MatchExpressions {
+					if targetsControlPlane(req) {
+						add(SchedulingControlPlaneSelector, "required node affinity for "+req.Key)
+					}
+				}
+			}
+		}
+		for _, preferred := range affinity.Preferred {
+			for _, req := range preferred.Preference.MatchExpressions {
+				if targetsControlPlane(req) {
+					add(SchedulingControlPlaneSelector, "preferred node affinity for "+req.Key)
+				}
+			}
+		}
+	}
+	return issues, evidence
+}
+
+// GetSchedulingEscapes finds workloads that can be scheduled onto control plane
+// nodes: tolerations for the control plane taint or for every taint, nodeName pins
+// and nodeSelectors or node affinity for control plane labels. Each is checked
+// against the control plane Nodes in the snapshot to confirm where it can run.
+// Static control plane pods, which belong there, are skipped.
+func GetSchedulingEscapes(config *ClusterConfig) []SchedulingEscape {
+	var nodes []schedulingNode
+	controlPlane := make(map[string]bool)
+	for _, item := range config.Items {
+		if item.Kind != "Node" {
+			continue
+		}
+		var spec nodeSpec
+		decodeInto(item.Spec, &spec)
+		node := schedulingNode{
+			name:          item.Metadata.Name,
+			labels:        item.Metadata.Labels,
+			taints:        spec.Taints,
+			unschedulable: spec.Unschedulable,
+		}
+		if node.isControlPlane() {
+			nodes = append(nodes, node)
+			controlPlane[node.name] = true
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].name < nodes[j].name })
+
+	var results []SchedulingEscape
+	for _, template := range GetPodTemplates(config) {
+		if template.IsManaged() || (template.Kind == "Pod" && isControlPlanePod(template.Name)) {
+			continue
+		}
+		if _, mirror := template.Annotations["kubernetes.io/config.mirror"]; mirror {
+			continue // other static pods
+		}
+
+		issues, evidence := schedulingIssues(template, controlPlane)
+		if len(issues) == 0 {
+			continue
+		}
+		escape := SchedulingEscape{
+			Kind:         template.Kind,
+			Name:         template.Name,
+			Namespace:    template.Namespace,
+			Issues:       issues,
+			Evidence:     evidence,
+			Confirmation: SchedulingUnverified,
+			HostEscapes:  hostEscapes(template),
+		}
+		if len(nodes) > 0 {
+			escape.Confirmation = SchedulingNotMatched
+			for _, node := range nodes {
+				if node.admits(template.Spec) {
+					escape.ControlPlaneNodes = append(escape.ControlPlaneNodes, node.name)
+					escape.Confirmation = SchedulingConfirmed
+				}
+			}
+		}
+		results = append(results, escape)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return results
+}
+
+// schedulingFindings adapts GetSchedulingEscapes to findings. Workloads confirmed to
+// reach a control plane node are high severity, and critical when they can also take
+// over the node; unverified escapes are medium and those no node admits low.
+func schedulingFindings(config *ClusterConfig) []Finding {
+	var findings []Finding
+	for _, e := range GetSchedulingEscapes(config) {
+		details := strings.Join(e.Evidence, ", ")
+		switch e.Confirmation {
+		case SchedulingConfirmed:
+			details += "; runs on control plane nodes " + strings.Join(e.ControlPlaneNodes, ", ")
+		case SchedulingNotMatched:
+			details += "; no control plane node in the snapshot admits it"
+		default:
+			details += "; no control plane nodes in the snapshot to confirm"
+		}
+		if len(e.HostEscapes) > 0 {
+			details += "; " + strings.Join(e.HostEscapes, ", ")
+		}
+
+		finding := Finding{
+			Namespace: e.Namespace,
+			Kind:      e.Kind,
+			Name:      e.Name,
+			Details:   details,
+			Severity:  SeverityMedium,
+		}
+		switch {
+		case e.Confirmation == SchedulingConfirmed && len(e.HostEscapes) > 0:
+			finding.Severity = SeverityCritical
+		case e.Confirmation == SchedulingConfirmed:
+			finding.Severity = SeverityHigh
+		case e.Confirmation == SchedulingNotMatched:
+			finding.Severity = SeverityLow
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
@@ -0,0 +1,379 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Secret exposure rules
+const (
+	SecretRuleAWSAccessKey   = "aws-access-key"
+	SecretRulePrivateKey     = "private-key"
+	SecretRuleJWT            = "jwt"
+	SecretRuleGitHubToken    = "github-token"
+	SecretRuleSlackToken     = "slack-token"
+	SecretRuleGoogleAPIKey   = "google-api-key"
+	SecretRuleURLCredentials = "url-credentials"     // user:password@ in a URL
+	SecretRuleCredentialName = "credential-name"     // a password/token/key named value
+	SecretRuleHighEntropy    = "high-entropy"        // a long random-looking string
+	SecretRuleSecretEnv      = "secret-env"          // a Secret key exposed as an env var
+	SecretRuleAppliedSecret  = "secret-last-applied" // Secret data copied into last-applied-configuration
+)
+
+// secretPatterns are the known token formats, checked in order
+var secretPatterns = []struct {
+	rule    string
+	pattern *regexp.Regexp
+}{
+	{SecretRulePrivateKey, regexp.MustCompile(`-----BEGIN ([A-Z0-9]+ )*PRIVATE KEY( BLOCK)?-----`)},
+	{SecretRuleAWSAccessKey, regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{SecretRuleJWT, regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+	{SecretRuleGitHubToken, regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{40,})\b`)},
+	{SecretRuleSlackToken, regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{SecretRuleGoogleAPIKey, regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{SecretRuleURLCredentials, regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^/\s:@]+:[^/\s@]+@`)},
+}
+
+// credentialName matches names of keys that usually hold credentials
+var credentialName = regexp.MustCompile(`(?i)(passw(or)?d|passwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key|credential|auth)`)
+
+// referenceName matches names of keys that hold the name of a Secret or another object
+// rather than a credential, such as secretName, tokenSecretName or TLS_SECRET_NAME
+var referenceName = regexp.MustCompile(`(?i)(secret|ref)[-_]?name$`)
+
+// secretAnnotation matches annotation keys such as nginx.ingress.kubernetes.io/auth-secret,
+// which name the Secret to use
+var secretAnnotation = regexp.MustCompile(`(?i)[-_]secret$`)
+
+// objectName matches a Kubernetes object name, optionally prefixed by its namespace
+var objectName = regexp.MustCompile(`^([a-z0-9]([-a-z0-9.]*[a-z0-9])?/)?[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+
+// checksumName matches names of keys holding checksums, such as Helm's checksum/secret
+// annotations, which look random but reveal nothing
+var checksumName = regexp.MustCompile(`(?i)(checksum|digest|hash)`)
+
+// Thresholds for values that are reported without a known format. Credential-named
+// values have a much lower bar than other strings, as hardcoded passwords are short;
+// mixing character classes keeps words such as "enabled" or "github" out.
+const (
+	credentialMinLength  = 6
+	credentialMinEntropy = 2.5 // bits per character, to skip values like "aaaaaa1"
+	credentialMinClasses = 2   // of lower case, upper case, digits and symbols
+	highEntropyMinLength = 24
+	highEntropyMin       = 4.5 // bits per character
+)
+
+// SecretExposure is a likely credential stored outside a Secret, or a Secret
+// exposed through an environment variable. The value itself is never kept.
+type SecretExposure struct {
+	Kind        string
+	Name        string
+	Namespace   string
+	Container   string
+	Location    string // e.g. "env DB_PASSWORD" or "data config.yaml"
+	Rule        string
+	Fingerprint string // redacted fingerprint of the value, see RedactSecret
+}
+
+// RedactSecret returns a fingerprint of a secret value that identifies it without
+// revealing any of it: its length and a truncated SHA-256, e.g.
+// "[20 chars, sha256:3b4f1c2d9e0a]". The same value always has the same fingerprint,
+// so it can be matched across objects and snapshots.
+func RedactSecret(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return fmt.Sprintf("[%d chars, sha256:%s]", len(value), hex.EncodeToString(sum[:])[:12])
+}
+
+// shannonEntropy returns the entropy of a string in bits per character
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	var entropy float64
+	length := float64(len([]rune(s)))
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// isHex reports whether a string is only hexadecimal digits, as checksums and digests are
+func isHex(s string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// characterClasses counts the classes of characters a string mixes: lower case,
+// upper case, digits and anything else
+func characterClasses(s string) int {
+	var lower, upper, digit, other int
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = 1
+		case r >= 'A' && r <= 'Z':
+			upper = 1
+		case r >= '0' && r <= '9':
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
+
+// isReference reports whether a key/value pair names an object, such as a Secret,
+// rather than holding a credential
+func isReference(key, value string) bool {
+	return referenceName.MatchString(key) || (secretAnnotation.MatchString(key) && objectName.MatchString(value))
+}
+
+// isPlainURL reports whether a value is a URL without user:password@ credentials
+func isPlainURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && u.Scheme != "" && u.Host != "" && u.User == nil
+}
+
+// ClassifySecret returns the rule a key/value pair matches as a likely credential,
+// and the part of the value that is the credential, or "" if it does not look like one.
+// References to Secrets, file paths and URLs without credentials are never reported by
+// name or entropy.
+func ClassifySecret(key, value string) (string, string) {
+	for _, p := range secretPatterns {
+		if match := p.pattern.FindString(value); match != "" {
+			if p.rule == SecretRulePrivateKey {
+				return p.rule, value // the header is the same for every key
+			}
+			return p.rule, match
+		}
+	}
+
+	trimmed := strings.TrimSpace(value)
+	if checksumName.MatchString(key) || strings.ContainsAny(trimmed, " \t\n") || strings.Contains(trimmed, "${") {
+		return "", "" // checksums, prose, config files and templated placeholders
+	}
+	if isReference(key, trimmed) || isPlainURL(trimmed) || strings.HasPrefix(trimmed, "/") {
+		return "", ""
+	}
+	entropy := shannonEntropy(trimmed)
+	if credentialName.MatchString(key) && len(trimmed) >= credentialMinLength &&
+		entropy >= credentialMinEntropy && characterClasses(trimmed) >= credentialMinClasses {
+		return SecretRuleCredentialName, trimmed
+	}
+	if len(trimmed) >= highEntropyMinLength && entropy >= highEntropyMin && !isHex(trimmed) &&
+		!strings.Contains(trimmed, "/") {
+		return SecretRuleHighEntropy, trimmed
+	}
+	return "", ""
+}
+
+// FindSecretExposures scans container env values, ConfigMap data and annotations,
+// including the manifests in last-applied-configuration, for likely credentials, and
+// reports containers that take Secrets as environment variables rather than volumes
+func FindSecretExposures(config *ClusterConfig) []SecretExposure {
+	var results []SecretExposure
+	seen := make(map[string]bool) // one report per object and value
+
+	for _, item := range config.Items {
+		base := SecretExposure{
+			Kind:      item.Kind,
+			Name:      item.Metadata.Name,
+			Namespace: item.Metadata.Namespace,
+		}
+		report := func(container, location, rule, value string) {
+			key := fmt.Sprintf("%s/%s/%s/%s", item.Kind, item.Metadata.Namespace, item.Metadata.Name, value)
+			if rule != SecretRuleSecretEnv {
+				if seen[key] {
+					return
+				}
+				seen[key] = true
+			}
+			exposure := base
+			exposure.Container, exposure.Location, exposure.Rule = container, location, rule
+			if value != "" {
+				exposure.Fingerprint = RedactSecret(value)
+			}
+			results = append(results, exposure)
+		}
+		scan := func(container, location, key, value string) {
+			if rule, match := ClassifySecret(key, value); rule != "" {
+				report(container, location, rule, match)
+			}
+		}
+
+		if template, ok := GetPodTemplate(item); ok && !template.IsManaged() {
+			for _, container := range template.Spec.PodContainers() {
+				for _, env := range container.Env {
+					if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+						report(container.Name, "env "+env.Name+" from Secret "+env.ValueFrom.SecretKeyRef.Name, SecretRuleSecretEnv, "")
+						continue
+					}
+					scan(container.Name, "env "+env.Name, env.Name, env.Value)
+				}
+				for _, from := range container.EnvFrom {
+					if from.SecretRef != nil {
+						report(container.Name, "envFrom Secret "+from.SecretRef.Name, SecretRuleSecretEnv, "")
+					}
+				}
+			}
+		}
+
+		if item.Kind == "ConfigMap" {
+			var data map[string]string
+			item.DecodeField("data", &data)
+			keys := make([]string, 0, len(data))
+			for key := range data {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				// A PEM file is reported whole; other files line by line
+				if rule, match := ClassifySecret(key, data[key]); rule == SecretRulePrivateKey {
+					report("", "data "+key, rule, match)
+					continue
+				}
+				for _, line := range strings.Split(data[key], "\n") {
+					lineKey, lineValue := splitAssignment(line, key)
+					location := "data " + key
+					if lineKey != key {
+						location += ": " + lineKey
+					}
+					scan("", location, lineKey, lineValue)
+				}
+			}
+		}
+
+		annotations := make([]string, 0, len(item.Metadata.Annotations))
+		for key := range item.Metadata.Annotations {
+			annotations = append(annotations, key)
+		}
+		sort.Strings(annotations)
+		for _, key := range annotations {
+			value := item.Metadata.Annotations[key]
+			if key != lastAppliedAnnotation {
+				scan("", "annotation "+key, key, value)
+				continue
+			}
+
+			var manifest interface{}
+			if err := json.Unmarshal([]byte(value), &manifest); err != nil {
+				continue
+			}
+			walkStrings(manifest, "", func(path, s string) {
+				// Secret data in a Secret's own last-applied-configuration is readable
+				// by anyone who can get the object's metadata
+				if item.Kind == "Secret" && (strings.HasPrefix(path, "data.") || strings.HasPrefix(path, "stringData.")) {
+					report("", "annotation "+key+": "+path, SecretRuleAppliedSecret, s)
+					return
+				}
+				// The name in a secretKeyRef, secretRef or other *Ref names an object
+				segments := strings.Split(path, ".")
+				if len(segments) > 1 && segments[len(segments)-1] == "name" &&
+					strings.HasSuffix(strings.ToLower(segments[len(segments)-2]), "ref") {
+					return
+				}
+				scan("", "annotation "+key+": "+path, segments[len(segments)-1], s)
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return results
+}
+
+// splitAssignment splits a "key: value" or "key=value" line. Lines that are not
+// assignments are returned whole under the fallback key.
+func splitAssignment(line, fallback string) (string, string) {
+	if i := strings.IndexAny(line, ":="); i > 0 {
+		key := strings.TrimSpace(line[:i])
+		if key != "" && !strings.ContainsAny(key, " \t") {
+			return key, strings.Trim(strings.TrimSpace(line[i+1:]), `"'`)
+		}
+	}
+	return fallback, line
+}
+
+// walkStrings calls fn for every string in decoded JSON with its dotted path; the
+// name of an env entry is used in place of its list index
+func walkStrings(value interface{}, path string, fn func(path, s string)) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	switch v := value.(type) {
+	case string:
+		fn(path, v)
+	case map[string]interface{}:
+		// {"name": "DB_PASSWORD", "value": "..."} is reported under the variable's name
+		if name, ok := v["name"].(string); ok {
+			if s, ok := v["value"].(string); ok {
+				fn(join(name), s)
+				return
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			walkStrings(v[key], join(key), fn)
+		}
+	case []interface{}:
+		for i, element := range v {
+			walkStrings(element, join(fmt.Sprint(i)), fn)
+		}
+	}
+}
+
+// secretFindings adapts FindSecretExposures to findings. Secrets passed as environment
+// variables are low severity and high-entropy strings without a known format medium.
+func secretFindings(config *ClusterConfig) []Finding {
+	var findings []Finding
+	for _, e := range FindSecretExposures(config) {
+		finding := Finding{
+			Namespace: e.Namespace,
+			Kind:      e.Kind,
+			Name:      e.Name,
+			Container: e.Container,
+			Rule:      e.Location,
+			Details:   fmt.Sprintf("%s in %s: %s", e.Rule, e.Location, e.Fingerprint),
+		}
+		switch e.Rule {
+		case SecretRuleSecretEnv:
+			finding.Severity = SeverityLow
+			finding.Details = "Secret exposed as environment variable: " + e.Location
+		case SecretRuleHighEntropy, SecretRuleCredentialName:
+			finding.Severity = SeverityMedium
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
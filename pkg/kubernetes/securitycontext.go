@@ -0,0 +1,180 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Levels a value of an effective security context is set at
+const (
+	LevelContainer = "container"
+	LevelPod       = "pod"
+	LevelDefault   = "default" // set at neither level
+)
+
+// Container types
+const (
+	ContainerRegular   = "container"
+	ContainerInit      = "init"
+	ContainerEphemeral = "ephemeral" // added with kubectl debug
+)
+
+// SecuritySetting is one value of a container's effective security context and the
+// level that set it
+type SecuritySetting struct {
+	Field string // e.g. "runAsUser" or "seccompProfile.type"
+	Value string // empty when unset at both levels
+	Level string
+}
+
+// String formats the setting, e.g. "runAsUser=0 (pod)"
+func (s SecuritySetting) String() string {
+	return fmt.Sprintf("%s=%s (%s)", s.Field, s.Value, s.Level)
+}
+
+// ContainerTypes maps each container of a pod spec to its type
+func (s PodSpec) ContainerTypes() map[string]string {
+	types := make(map[string]string)
+	for _, c := range s.Containers {
+		types[c.Name] = ContainerRegular
+	}
+	for _, c := range s.InitContainers {
+		types[c.Name] = ContainerInit
+	}
+	for _, c := range s.EphemeralContainers {
+		types[c.Name] = ContainerEphemeral
+	}
+	return types
+}
+
+// EffectiveSecurityContext merges a container's security context with the pod level
+// defaults it inherits. Container values override the pod's; each setting records the
+// level it came from.
+func EffectiveSecurityContext(spec PodSpec, c Container) []SecuritySetting {
+	pod := spec.SecurityContext
+	if pod == nil {
+		pod = &PodSecurityContext{}
+	}
+	sc := c.SecurityContext
+	if sc == nil {
+		sc = &SecurityContext{}
+	}
+
+	var settings []SecuritySetting
+	add := func(field, containerValue, podValue string) {
+		setting := SecuritySetting{Field: field, Level: LevelDefault}
+		switch {
+		case containerValue != "":
+			setting.Value, setting.Level = containerValue, LevelContainer
+		case podValue != "":
+			setting.Value, setting.Level = podValue, LevelPod
+		}
+		settings = append(settings, setting)
+	}
+
+	add("privileged", formatBool(sc.Privileged), "")
+	add("allowPrivilegeEscalation", formatBool(sc.AllowPrivilegeEscalation), "")
+	add("capabilities.add", strings.Join(c.AddedCapabilities(), ","), "")
+	add("runAsUser", formatInt64(sc.RunAsUser), formatInt64(pod.RunAsUser))
+	add("runAsNonRoot", formatBool(sc.RunAsNonRoot), formatBool(pod.RunAsNonRoot))
+	add("seccompProfile.type", seccompType(sc.SeccompProfile), seccompType(pod.SeccompProfile))
+	add("appArmorProfile.type", appArmorType(sc.AppArmorProfile), appArmorType(pod.AppArmorProfile))
+	add("seLinuxOptions.type", seLinuxType(sc.SELinuxOptions), seLinuxType(pod.SELinuxOptions))
+	add("procMount", sc.ProcMount, "")
+	return settings
+}
+
+// RiskySecuritySettings returns the values of a container's effective security context
+// that weaken its isolation: privileged, explicit privilege escalation, added
+// capabilities, running as UID 0, unconfined seccomp or AppArmor, the spc_t SELinux
+// type and an unmasked /proc
+func RiskySecuritySettings(spec PodSpec, c Container) []SecuritySetting {
+	var risky []SecuritySetting
+	for _, s := range EffectiveSecurityContext(spec, c) {
+		switch s.Field {
+		case "privileged", "allowPrivilegeEscalation":
+			if s.Value == "true" {
+				risky = append(risky, s)
+			}
+		case "capabilities.add":
+			if s.Value != "" {
+				risky = append(risky, s)
+			}
+		case "runAsUser":
+			if s.Value == "0" {
+				risky = append(risky, s)
+			}
+		case "seccompProfile.type", "appArmorProfile.type":
+			if s.Value == "Unconfined" {
+				risky = append(risky, s)
+			}
+		case "seLinuxOptions.type":
+			if s.Value == "spc_t" {
+				risky = append(risky, s)
+			}
+		case "procMount":
+			if s.Value == "Unmasked" {
+				risky = append(risky, s)
+			}
+		}
+	}
+	return risky
+}
+
+func formatBool(value *bool) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatBool(*value)
+}
+
+func formatInt64(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(*value, 10)
+}
+
+func seccompType(profile *SeccompProfile) string {
+	if profile == nil {
+		return ""
+	}
+	return profile.Type
+}
+
+func appArmorType(profile *AppArmorProfile) string {
+	if profile == nil {
+		return ""
+	}
+	return profile.Type
+}
+
+func seLinuxType(options *SELinuxOptions) string {
+	if options == nil {
+		return ""
+	}
+	return options.Type
+}
+
+// formatSecuritySettings joins settings for display, e.g. "privileged=true (container), runAsUser=0 (pod)"
+func formatSecuritySettings(settings []SecuritySetting) string {
+	parts := make([]string, len(settings))
+	for i, s := range settings {
+		parts[i] = s.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// securityContextDetails describes a finding on a container with its type, when it is
+// not a regular container, and the risky settings of its effective security context
+func securityContextDetails(summary, containerType string, settings []SecuritySetting) string {
+	details := summary
+	if containerType != "" && containerType != ContainerRegular {
+		details += fmt.Sprintf(" (%s container)", containerType)
+	}
+	if len(settings) > 0 {
+		details += "; effective: " + formatSecuritySettings(settings)
+	}
+	return details
+}
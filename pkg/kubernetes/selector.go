@@ -0,0 +1,62 @@
+package kubernetes
+
+// LabelSelector selects resources by label, as used by NetworkPolicies and workload specs
+type LabelSelector struct {
+	MatchLabels      map[string]string          `json:"matchLabels,omitempty"`
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+}
+
+// LabelSelectorRequirement is a single set-based selector expression
+type LabelSelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// IsEmpty reports whether the selector has no requirements, so it matches everything
+func (s LabelSelector) IsEmpty() bool {
+	return len(s.MatchLabels) == 0 && len(s.MatchExpressions) == 0
+}
+
+// Matches reports whether a set of labels satisfies the selector
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	if !MatchesSelector(s.MatchLabels, labels) {
+		return false
+	}
+
+	for _, req := range s.MatchExpressions {
+		value, exists := labels[req.Key]
+		switch req.Operator {
+		case "In":
+			if !exists || !containsString(req.Values, value) {
+				return false
+			}
+		case "NotIn":
+			if exists && containsString(req.Values, value) {
+				return false
+			}
+		case "Exists":
+			if !exists {
+				return false
+			}
+		case "DoesNotExist":
+			if exists {
+				return false
+			}
+		default:
+			return false // unknown operators match nothing, as in the API server
+		}
+	}
+	return true
+}
+
+// MatchesSelector reports whether labels contain every key/value of an equality-based
+// selector, such as a Service's spec.selector. An empty selector matches everything.
+func MatchesSelector(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,215 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ServiceAccount token issues
+const (
+	TokenAutomounted    = "automounted-token"       // a token with RBAC bindings is mounted into the pod
+	TokenLegacySecret   = "legacy-token-secret"     // a long-lived kubernetes.io/service-account-token Secret
+	TokenDefaultAccount = "default-service-account" // the workload runs as its namespace's default service account
+)
+
+// serviceAccountTokenType is the type of Secrets holding long-lived service account tokens
+const serviceAccountTokenType = "kubernetes.io/service-account-token"
+
+// ServiceAccountToken is a workload or Secret exposing a service account token
+type ServiceAccountToken struct {
+	Kind            string
+	Name            string
+	Namespace       string
+	ServiceAccount  string
+	Issue           string
+	AutomountSource string   // where automounting is enabled: "pod", "service account" or "default"
+	Bindings        []string // Kind/name of the bindings granting the service account roles
+	ClusterAdmin    bool     // the service account is bound to cluster-admin or equivalent
+}
+
+// String describes the issue, e.g. "token of app automounted (default) with bindings RoleBinding/app"
+func (t ServiceAccountToken) String() string {
+	bindings := "no RBAC bindings"
+	if len(t.Bindings) > 0 {
+		bindings = "bindings " + strings.Join(t.Bindings, ", ")
+	}
+	switch t.Issue {
+	case TokenAutomounted:
+		return fmt.Sprintf("Token of %s automounted (%s) with %s", t.ServiceAccount, t.AutomountSource, bindings)
+	case TokenLegacySecret:
+		return fmt.Sprintf("Long-lived token Secret for %s with %s", t.ServiceAccount, bindings)
+	default:
+		if t.AutomountSource == "" {
+			return "Uses the default service account (token not mounted)"
+		}
+		return fmt.Sprintf("Uses the default service account (token automounted, %s)", t.AutomountSource)
+	}
+}
+
+// serviceAccountBinding is the RBAC access of one service account
+type serviceAccountBinding struct {
+	bindings     []string
+	clusterAdmin bool
+}
+
+// serviceAccountBindings maps namespace/name of each service account to the bindings
+// that grant it roles, directly or through the system:serviceaccounts groups
+func serviceAccountBindings(config *ClusterConfig) func(namespace, name string) serviceAccountBinding {
+	direct := make(map[string]serviceAccountBinding)
+	groups := make(map[string]serviceAccountBinding) // keyed by namespace, "" for every namespace
+	for _, subject := range GetRBACPermissions(config) {
+		var access serviceAccountBinding
+		for _, grant := range subject.Grants {
+			access.bindings = appendUnique(access.bindings, grant.Binding)
+		}
+		access.clusterAdmin = subject.ClusterAdmin
+
+		switch {
+		case subject.Kind == "ServiceAccount":
+			direct[namespaceOrDefault(subject.Namespace)+"/"+subject.Name] = access
+		case subject.Kind == "Group" && subject.Name == "system:serviceaccounts":
+			groups[""] = access
+		case subject.Kind == "Group" && strings.HasPrefix(subject.Name, "system:serviceaccounts:"):
+			groups[strings.TrimPrefix(subject.Name, "system:serviceaccounts:")] = access
+		}
+	}
+
+	return func(namespace, name string) serviceAccountBinding {
+		namespace = namespaceOrDefault(namespace)
+		var access serviceAccountBinding
+		for _, a := range []serviceAccountBinding{direct[namespace+"/"+name], groups[namespace], groups[""]} {
+			for _, binding := range a.bindings {
+				access.bindings = appendUnique(access.bindings, binding)
+			}
+			access.clusterAdmin = access.clusterAdmin || a.clusterAdmin
+		}
+		return access
+	}
+}
+
+// GetServiceAccountTokens finds pods that automount the token of a service account
+// with RBAC bindings, long-lived kubernetes.io/service-account-token Secrets and
+// workloads running as the default service account. Automounting is taken from the
+// pod spec, then the ServiceAccount, and is on when neither sets it.
+func GetServiceAccountTokens(config *ClusterConfig) []ServiceAccountToken {
+	automount := make(map[string]*bool) // namespace/name of ServiceAccounts that set it
+	for _, item := range config.Items {
+		if item.Kind != "ServiceAccount" {
+			continue
+		}
+		var enabled *bool
+		item.DecodeField("automountServiceAccountToken", &enabled)
+		automount[namespaceOrDefault(item.Metadata.Namespace)+"/"+item.Metadata.Name] = enabled
+	}
+	bindingsOf := serviceAccountBindings(config)
+
+	var results []ServiceAccountToken
+	for _, item := range config.Items {
+		base := ServiceAccountToken{
+			Kind:      item.Kind,
+			Name:      item.Metadata.Name,
+			Namespace: item.Metadata.Namespace,
+		}
+
+		if item.Kind == "Secret" {
+			var secretType string
+			item.DecodeField("type", &secretType)
+			if secretType != serviceAccountTokenType {
+				continue
+			}
+			token := base
+			token.Issue = TokenLegacySecret
+			token.ServiceAccount = item.Metadata.Annotations["kubernetes.io/service-account.name"]
+			if token.ServiceAccount == "" {
+				token.ServiceAccount = "default"
+			}
+			access := bindingsOf(item.Metadata.Namespace, token.ServiceAccount)
+			token.Bindings, token.ClusterAdmin = access.bindings, access.clusterAdmin
+			results = append(results, token)
+			continue
+		}
+
+		template, ok := GetPodTemplate(item)
+		if !ok || template.IsManaged() {
+			continue
+		}
+		account := template.Spec.ServiceAccountName
+		if account == "" {
+			account = "default"
+		}
+
+		// The pod setting overrides the service account's
+		source := "default"
+		mounted := true
+		if enabled := template.Spec.AutomountServiceAccountToken; enabled != nil {
+			source, mounted = "pod", *enabled
+		} else if enabled := automount[namespaceOrDefault(item.Metadata.Namespace)+"/"+account]; enabled != nil {
+			source, mounted = "service account", *enabled
+		}
+		if !mounted {
+			source = ""
+		}
+
+		access := bindingsOf(item.Metadata.Namespace, account)
+		if mounted && len(access.bindings) > 0 {
+			token := base
+			token.Issue = TokenAutomounted
+			token.ServiceAccount = account
+			token.AutomountSource = source
+			token.Bindings, token.ClusterAdmin = access.bindings, access.clusterAdmin
+			results = append(results, token)
+		}
+		if account == "default" {
+			token := base
+			token.Issue = TokenDefaultAccount
+			token.ServiceAccount = account
+			token.AutomountSource = source
+			token.Bindings, token.ClusterAdmin = access.bindings, access.clusterAdmin
+			results = append(results, token)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return results
+}
+
+// serviceAccountFindings adapts GetServiceAccountTokens to findings. Automounted tokens
+// of cluster admins are critical, legacy token Secrets medium and workloads running as
+// the default service account low severity.
+func serviceAccountFindings(config *ClusterConfig) []Finding {
+	var findings []Finding
+	for _, t := range GetServiceAccountTokens(config) {
+		finding := Finding{
+			Namespace: t.Namespace,
+			Kind:      t.Kind,
+			Name:      t.Name,
+			Rule:      t.Issue,
+			Details:   t.String(),
+		}
+		switch t.Issue {
+		case TokenAutomounted:
+			if t.ClusterAdmin {
+				finding.Severity = SeverityCritical
+			}
+		case TokenLegacySecret:
+			finding.Severity = SeverityMedium
+			if t.ClusterAdmin {
+				finding.Severity = SeverityCritical
+			}
+		case TokenDefaultAccount:
+			finding.Severity = SeverityLow
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
@@ -0,0 +1,260 @@
+package kubernetes
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ItemHandler is called for every resource decoded from a configuration stream
+type ItemHandler func(item Item) error
+
+// DecodeStream walks a JSON configuration one item at a time without holding the
+// raw document in memory. For a List only one item is decoded at a time before it is
+// handed to fn; any other top-level fields are skipped. A bare object (no items
+// array) is passed to fn as a single item.
+func DecodeStream(r io.Reader, fn ItemHandler) error {
+	dec := json.NewDecoder(bufio.NewReaderSize(r, 1<<20))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	switch tok {
+	case json.Delim('['):
+		// A bare array may hold things that are not Kubernetes objects, which are skipped
+		return decodeItemArray(dec, func(item Item) error {
+			if item.Kind == "" {
+				return nil
+			}
+			return fn(item)
+		})
+	case json.Delim('{'):
+	default:
+		return fmt.Errorf("unexpected token %v at start of configuration", tok)
+	}
+
+	// Walk the top-level object, streaming the items array and keeping any
+	// other (small) fields in case this turns out to be a single object
+	fields := make(map[string]json.RawMessage)
+	sawItems := false
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to read configuration: %w", err)
+		}
+		key, ok := keyTok.(string)
+		if !ok {
+			return fmt.Errorf("unexpected token %v in configuration", keyTok)
+		}
+
+		if key == "items" {
+			sawItems = true
+			tok, err := dec.Token()
+			if err != nil {
+				return fmt.Errorf("failed to read items: %w", err)
+			}
+			if tok == nil {
+				continue // "items": null
+			}
+			if tok != json.Delim('[') {
+				return fmt.Errorf("expected items to be an array, got %v", tok)
+			}
+			if err := decodeItemArray(dec, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("failed to read field %q: %w", key, err)
+		}
+		fields[key] = value
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	if sawItems {
+		return nil
+	}
+
+	// No items array: treat the document as a single Kubernetes object
+	var kind string
+	if raw, ok := fields["kind"]; ok {
+		json.Unmarshal(raw, &kind)
+	}
+	if kind == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to decode object: %w", err)
+	}
+	var item Item
+	if err := json.Unmarshal(data, &item); err != nil {
+		return fmt.Errorf("failed to decode object: %w", err)
+	}
+	return fn(item)
+}
+
+// DecodeYAMLStream walks a YAML configuration one document at a time, converting each
+// to JSON and passing its resources to fn. Only the current document is held in memory,
+// which bounds manifest bundles but not the single List document written by
+// 'kubectl get -o yaml'; very large dumps should be taken as JSON.
+func DecodeYAMLStream(r io.Reader, fn ItemHandler) error {
+	reader := bufio.NewReaderSize(r, 1<<20)
+	var document bytes.Buffer
+	index := 0
+
+	flush := func() error {
+		index++
+		jsonData, err := yaml.YAMLToJSON(document.Bytes())
+		document.Reset()
+		if err != nil {
+			return fmt.Errorf("document %d: %w", index, err)
+		}
+		var parsed ClusterConfig
+		if err := appendDocument(&parsed, jsonData); err != nil {
+			return fmt.Errorf("document %d: %w", index, err)
+		}
+		for _, item := range parsed.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if isDocumentSeparator(strings.TrimRight(line, "\r\n")) {
+			if err := flush(); err != nil {
+				return err
+			}
+		} else {
+			document.WriteString(line)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read configuration: %w", err)
+		}
+	}
+	return flush()
+}
+
+// DecodeConfigStream sniffs whether r holds JSON or YAML and walks it item by item
+// with DecodeStream or DecodeYAMLStream, returning the detected format
+func DecodeConfigStream(r io.Reader, fn ItemHandler) (Format, error) {
+	reader := bufio.NewReaderSize(r, 1<<20)
+	head, _ := reader.Peek(512)
+	format := DetectFormat(head)
+	if format == FormatJSON {
+		return format, DecodeStream(reader, fn)
+	}
+	return format, DecodeYAMLStream(reader, fn)
+}
+
+// decodeItemArray decodes the elements of an array whose opening bracket has
+// already been consumed, calling fn for each one
+func decodeItemArray(dec *json.Decoder, fn ItemHandler) error {
+	index := 0
+	for dec.More() {
+		var item Item
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("failed to decode item %d: %w", index, err)
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+		index++
+	}
+
+	// Consume the closing bracket
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to read end of items: %w", err)
+	}
+	return nil
+}
+
+// ParseConfigStream builds a configuration from a JSON stream item by item.
+// Unlike ParseConfig the raw document is never held in memory in full.
+func ParseConfigStream(r io.Reader) (*ClusterConfig, error) {
+	config := &ClusterConfig{
+		ApiVersion: "v1",
+		Kind:       "List",
+	}
+
+	err := DecodeStream(r, func(item Item) error {
+		config.Items = append(config.Items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Kubernetes configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// EncodeStream writes a configuration as an indented JSON List one item at a time,
+// so the whole document never has to be marshalled into a single buffer
+func EncodeStream(w io.Writer, config *ClusterConfig) error {
+	enc := NewItemEncoder(w, config.ApiVersion, config.Kind)
+	for _, item := range config.Items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
+
+// ItemEncoder writes an indented JSON List as its items arrive, so a configuration
+// can be saved while it is still being decoded
+type ItemEncoder struct {
+	bw    *bufio.Writer
+	count int
+}
+
+// NewItemEncoder writes the header of a List with the given apiVersion and kind
+func NewItemEncoder(w io.Writer, apiVersion, kind string) *ItemEncoder {
+	bw := bufio.NewWriter(w)
+	version, _ := json.Marshal(apiVersion)
+	kindJSON, _ := json.Marshal(kind)
+	fmt.Fprintf(bw, "{\n  \"apiVersion\": %s,\n  \"kind\": %s,\n  \"items\": [", version, kindJSON)
+	return &ItemEncoder{bw: bw}
+}
+
+// Encode appends one item to the List
+func (e *ItemEncoder) Encode(item Item) error {
+	data, err := json.MarshalIndent(item, "    ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal item %d: %w", e.count, err)
+	}
+	if e.count > 0 {
+		e.bw.WriteString(",")
+	}
+	e.bw.WriteString("\n    ")
+	e.bw.Write(data)
+	e.count++
+	return nil
+}
+
+// Close ends the List and flushes it; it does not close the underlying writer
+func (e *ItemEncoder) Close() error {
+	if e.count > 0 {
+		e.bw.WriteString("\n  ")
+	}
+	e.bw.WriteString("]\n}\n")
+	return e.bw.Flush()
+}
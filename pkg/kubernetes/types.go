@@ -1,5 +1,9 @@
 package kubernetes
 
+import (
+	"encoding/json"
+)
+
 // ClusterConfig represents the top-level structure for Kubernetes cluster configuration
 type ClusterConfig struct {
 	ApiVersion string `json:"apiVersion,omitempty"`
@@ -14,12 +18,81 @@ type Item struct {
 	Metadata   Metadata `json:"metadata,omitempty"`
 	Spec       interface{} `json:"spec,omitempty"`
 	Status     interface{} `json:"status,omitempty"`
+	Source     string      `json:"source,omitempty"` // file the item was loaded from (directory and archive ingest)
+	// Fields holds any other top-level fields, such as rules and subjects on RBAC
+	// objects or data on Secrets and ConfigMaps
+	Fields map[string]interface{} `json:"-"`
+}
+
+// itemFields are the top-level fields decoded into Item's own struct fields
+var itemFields = map[string]bool{
+	"apiVersion": true, "kind": true, "metadata": true, "spec": true, "status": true, "source": true,
+}
+
+// UnmarshalJSON decodes an item, keeping top-level fields without a struct field in Fields.
+// Each field is decoded once from its raw bytes.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*i = Item{}
+	for key, value := range raw {
+		var err error
+		switch key {
+		case "apiVersion":
+			err = json.Unmarshal(value, &i.ApiVersion)
+		case "kind":
+			err = json.Unmarshal(value, &i.Kind)
+		case "metadata":
+			err = json.Unmarshal(value, &i.Metadata)
+		case "spec":
+			err = json.Unmarshal(value, &i.Spec)
+		case "status":
+			err = json.Unmarshal(value, &i.Status)
+		case "source":
+			err = json.Unmarshal(value, &i.Source)
+		default:
+			var field interface{}
+			err = json.Unmarshal(value, &field)
+			if i.Fields == nil {
+				i.Fields = make(map[string]interface{})
+			}
+			i.Fields[key] = field
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes an item including the extra top-level fields in Fields
+func (i Item) MarshalJSON() ([]byte, error) {
+	type plain Item
+	data, err := json.Marshal(plain(i))
+	if err != nil || len(i.Fields) == 0 {
+		return data, err
+	}
+
+	var merged map[string]interface{}
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range i.Fields {
+		if !itemFields[key] {
+			merged[key] = value
+		}
+	}
+	return json.Marshal(merged)
 }
 
 // Metadata contains resource metadata
 type Metadata struct {
 	Name              string            `json:"name,omitempty"`
 	Namespace         string            `json:"namespace,omitempty"`
+	UID               string            `json:"uid,omitempty"`
 	Labels            map[string]string `json:"labels,omitempty"`
 	Annotations       map[string]string `json:"annotations,omitempty"`
 	CreationTimestamp string            `json:"creationTimestamp,omitempty"`
@@ -34,4 +107,57 @@ type OwnerReference struct {
 	UID                string `json:"uid,omitempty"`
 	Controller         bool   `json:"controller,omitempty"`
 	BlockOwnerDeletion bool   `json:"blockOwnerDeletion,omitempty"`
-}
\ No newline at end of file
+}
+
+// analysisStatusFields are the status fields an analyzer reads, by kind
+var analysisStatusFields = map[string][]string{
+	"Node":    {"capacity", "allocatable", "nodeInfo"},
+	"Pod":     {"phase"},
+	"Service": {"loadBalancer"},
+}
+
+// analysisFields are the extra top-level fields an analyzer reads. Secret data is not
+// among them, only ConfigMap data is scanned for credentials.
+var analysisFields = []string{"rules", "roleRef", "subjects", "type", "automountServiceAccountToken", "webhooks"}
+
+// Compact returns a copy of the item holding only what the analyzers read. Ingest
+// keeps compact items for analysis while the full items go to storage, which drops
+// most of the status, Secret data and CustomResourceDefinition schemas.
+func (i Item) Compact() Item {
+	compact := i
+	compact.Fields = nil
+	compact.Status = nil
+	if status, ok := i.Status.(map[string]interface{}); ok {
+		kept := make(map[string]interface{})
+		for _, key := range analysisStatusFields[i.Kind] {
+			if value, ok := status[key]; ok {
+				kept[key] = value
+			}
+		}
+		if len(kept) > 0 {
+			compact.Status = kept
+		}
+	}
+	if i.Kind == "CustomResourceDefinition" {
+		compact.Spec = nil
+	}
+	for key, value := range i.Fields {
+		if containsString(analysisFields, key) || (key == "data" && i.Kind == "ConfigMap") {
+			if compact.Fields == nil {
+				compact.Fields = make(map[string]interface{})
+			}
+			compact.Fields[key] = value
+		}
+	}
+	return compact
+}
+
+// DecodeField decodes one of the item's extra top-level fields into target.
+// It returns false if the field is not present or cannot be decoded.
+func (i Item) DecodeField(name string, target interface{}) bool {
+	value, ok := i.Fields[name]
+	if !ok {
+		return false
+	}
+	return decodeInto(value, target) == nil
+}
@@ -0,0 +1,310 @@
+package kubernetes
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Admission webhook issues
+const (
+	WebhookFailOpen        = "fail-open"              // a security webhook with failurePolicy Ignore
+	WebhookBroadExemption  = "broad-exemption"        // the namespaceSelector skips most namespaces
+	WebhookKubeSystem      = "intercepts-kube-system" // requests in kube-system go through the webhook
+	WebhookLongTimeout     = "long-timeout"           // timeoutSeconds above the default of 10
+	WebhookCertExpired     = "ca-expired"
+	WebhookCertExpiring    = "ca-expiring"
+	WebhookInvalidCABundle = "invalid-ca-bundle"
+)
+
+// Thresholds for webhook timeouts and caBundle certificates
+const (
+	webhookDefaultTimeout = 10 // seconds, in admissionregistration.k8s.io/v1
+	webhookExpiryWarning  = 30 * 24 * time.Hour
+)
+
+// securityWebhookName matches known policy engines, image verifiers and runtime security
+// controllers, and webhooks named for security policy. It is matched against the names of
+// the webhook, its configuration and its Service, never the resources it intercepts:
+// sidecar injectors and defaulting webhooks intercept pods too and are meant to fail open.
+var securityWebhookName = regexp.MustCompile(`(?i)(polic|gatekeeper|kyverno|\bopa\b|kubewarden|connaisseur|ratify|sigstore|cosign|image-?verif|neuvector|kubearmor|stackrox|twistlock|sysdig|falco|security|\bpsp\b|\bpss\b|admission-?control)`)
+
+// webhookRule is a rule of an admission webhook
+type webhookRule struct {
+	Operations  []string `json:"operations,omitempty"`
+	APIGroups   []string `json:"apiGroups,omitempty"`
+	APIVersions []string `json:"apiVersions,omitempty"`
+	Resources   []string `json:"resources,omitempty"`
+	Scope       string   `json:"scope,omitempty"` // Cluster, Namespaced or *
+}
+
+// webhookClientConfig is how the API server reaches a webhook
+type webhookClientConfig struct {
+	URL     string `json:"url,omitempty"`
+	Service *struct {
+		Namespace string `json:"namespace,omitempty"`
+		Name      string `json:"name,omitempty"`
+		Path      string `json:"path,omitempty"`
+		Port      int    `json:"port,omitempty"`
+	} `json:"service,omitempty"`
+	CABundle string `json:"caBundle,omitempty"`
+}
+
+// webhookSpec is one webhook of a Validating or MutatingWebhookConfiguration
+type webhookSpec struct {
+	Name              string              `json:"name,omitempty"`
+	ClientConfig      webhookClientConfig `json:"clientConfig,omitempty"`
+	Rules             []webhookRule       `json:"rules,omitempty"`
+	FailurePolicy     string              `json:"failurePolicy,omitempty"`
+	NamespaceSelector *LabelSelector      `json:"namespaceSelector,omitempty"`
+	TimeoutSeconds    *int                `json:"timeoutSeconds,omitempty"`
+}
+
+// WebhookCertificate is a certificate in a webhook's caBundle
+type WebhookCertificate struct {
+	Subject  string
+	NotAfter time.Time
+}
+
+// AdmissionWebhook is one webhook of a Validating or MutatingWebhookConfiguration
+type AdmissionWebhook struct {
+	Kind           string // ValidatingWebhookConfiguration or MutatingWebhookConfiguration
+	Configuration  string
+	Name           string
+	FailurePolicy  string
+	TimeoutSeconds int
+	Endpoint       string   // Service namespace/name[:port][/path] or URL
+	Resources      []string // resources the rules intercept
+	Security       bool     // a policy or security control, judged by its names, see securityWebhookName
+	Exempted       []string // namespaces the namespaceSelector skips, when the snapshot has them
+	Certificates   []WebhookCertificate
+	Issues         []string
+}
+
+// GetAdmissionWebhooks lists the webhooks of every Validating and MutatingWebhookConfiguration
+// and flags security webhooks that fail open or whose namespaceSelector skips most
+// namespaces, webhooks intercepting kube-system, long timeouts and caBundle certificates
+// that have expired or expire within 30 days of the Now option, the snapshot's time
+func GetAdmissionWebhooks(config *ClusterConfig, opts Options) []AdmissionWebhook {
+	// Namespaces and their labels, including the name label the API server adds
+	namespaces := make(map[string]map[string]string)
+	for _, item := range config.Items {
+		if item.Kind != "Namespace" {
+			continue
+		}
+		labels := map[string]string{"kubernetes.io/metadata.name": item.Metadata.Name}
+		for key, value := range item.Metadata.Labels {
+			labels[key] = value
+		}
+		namespaces[item.Metadata.Name] = labels
+	}
+	kubeSystem, ok := namespaces["kube-system"]
+	if !ok {
+		kubeSystem = map[string]string{"kubernetes.io/metadata.name": "kube-system"}
+	}
+
+	now := opts.now()
+	var results []AdmissionWebhook
+	for _, item := range config.Items {
+		if item.Kind != "ValidatingWebhookConfiguration" && item.Kind != "MutatingWebhookConfiguration" {
+			continue
+		}
+		var specs []webhookSpec
+		item.DecodeField("webhooks", &specs)
+
+		// v1beta1 defaults to failing open and to a 30 second timeout
+		failurePolicy, timeout := "Fail", webhookDefaultTimeout
+		if strings.HasSuffix(item.ApiVersion, "/v1beta1") {
+			failurePolicy, timeout = "Ignore", 30
+		}
+
+		for _, spec := range specs {
+			webhook := AdmissionWebhook{
+				Kind:           item.Kind,
+				Configuration:  item.Metadata.Name,
+				Name:           spec.Name,
+				FailurePolicy:  failurePolicy,
+				TimeoutSeconds: timeout,
+				Endpoint:       spec.ClientConfig.endpoint(),
+			}
+			if spec.FailurePolicy != "" {
+				webhook.FailurePolicy = spec.FailurePolicy
+			}
+			if spec.TimeoutSeconds != nil {
+				webhook.TimeoutSeconds = *spec.TimeoutSeconds
+			}
+
+			namespaced := false
+			for _, rule := range spec.Rules {
+				for _, resource := range rule.Resources {
+					webhook.Resources = appendUnique(webhook.Resources, resource)
+				}
+				if rule.Scope != "Cluster" {
+					namespaced = true
+				}
+			}
+			webhook.Security = securityWebhookName.MatchString(item.Metadata.Name + " " + spec.Name + " " + webhook.Endpoint)
+
+			// A missing or empty namespaceSelector matches every namespace
+			selector := LabelSelector{}
+			if spec.NamespaceSelector != nil {
+				selector = *spec.NamespaceSelector
+			}
+			for name, labels := range namespaces {
+				if !selector.Matches(labels) {
+					webhook.Exempted = append(webhook.Exempted, name)
+				}
+			}
+			sort.Strings(webhook.Exempted)
+
+			if webhook.Security && webhook.FailurePolicy == "Ignore" {
+				webhook.Issues = append(webhook.Issues, WebhookFailOpen)
+			}
+			if webhook.Security && len(webhook.Exempted) > 1 && len(webhook.Exempted)*2 > len(namespaces) {
+				webhook.Issues = append(webhook.Issues, WebhookBroadExemption)
+			}
+			if namespaced && len(spec.Rules) > 0 && selector.Matches(kubeSystem) {
+				webhook.Issues = append(webhook.Issues, WebhookKubeSystem)
+			}
+			if webhook.TimeoutSeconds > webhookDefaultTimeout {
+				webhook.Issues = append(webhook.Issues, WebhookLongTimeout)
+			}
+
+			if spec.ClientConfig.CABundle != "" {
+				certificates, err := parseCABundle(spec.ClientConfig.CABundle)
+				if err != nil {
+					webhook.Issues = append(webhook.Issues, WebhookInvalidCABundle)
+				}
+				webhook.Certificates = certificates
+				for _, certificate := range certificates {
+					if now.After(certificate.NotAfter) {
+						webhook.Issues = appendUnique(webhook.Issues, WebhookCertExpired)
+					} else if certificate.NotAfter.Sub(now) < webhookExpiryWarning {
+						webhook.Issues = appendUnique(webhook.Issues, WebhookCertExpiring)
+					}
+				}
+			}
+
+			results = append(results, webhook)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Configuration != b.Configuration {
+			return a.Configuration < b.Configuration
+		}
+		return a.Name < b.Name
+	})
+	return results
+}
+
+// endpoint formats where the API server sends admission requests
+func (c webhookClientConfig) endpoint() string {
+	if c.Service == nil {
+		return c.URL
+	}
+	endpoint := namespaceOrDefault(c.Service.Namespace) + "/" + c.Service.Name
+	if c.Service.Port != 0 && c.Service.Port != 443 {
+		endpoint += fmt.Sprintf(":%d", c.Service.Port)
+	}
+	return endpoint + c.Service.Path
+}
+
+// parseCABundle decodes the certificates of a base64-encoded PEM caBundle. The
+// certificates decoded before an error are returned with it.
+func parseCABundle(bundle string) ([]WebhookCertificate, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(bundle))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode caBundle: %w", err)
+	}
+
+	var certificates []WebhookCertificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return certificates, fmt.Errorf("failed to parse caBundle certificate: %w", err)
+		}
+		certificates = append(certificates, WebhookCertificate{
+			Subject:  certificate.Subject.String(),
+			NotAfter: certificate.NotAfter,
+		})
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("caBundle contains no certificates")
+	}
+	return certificates, nil
+}
+
+// webhookFindings adapts GetAdmissionWebhooks to findings. Expired CA certificates and
+// security webhooks failing open are high severity, as are webhooks that intercept
+// kube-system and fail closed; long timeouts are low.
+func webhookFindings(config *ClusterConfig, opts Options) []Finding {
+	now := opts.now()
+	var findings []Finding
+	for _, w := range GetAdmissionWebhooks(config, opts) {
+		for _, issue := range w.Issues {
+			finding := Finding{
+				Severity: SeverityMedium,
+				Kind:     w.Kind,
+				Name:     w.Configuration,
+				Rule:     w.Name + " " + issue,
+			}
+			if issue == WebhookCertExpired {
+				finding.Rule = w.Name + " " + WebhookCertExpiring // an expiring certificate that has now expired
+			}
+			var details string
+			switch issue {
+			case WebhookFailOpen:
+				finding.Severity = SeverityHigh
+				details = "security webhook has failurePolicy Ignore, so requests are admitted unchecked when it is unavailable"
+			case WebhookBroadExemption:
+				details = fmt.Sprintf("namespaceSelector exempts %d namespaces: %s", len(w.Exempted), strings.Join(w.Exempted, ", "))
+			case WebhookKubeSystem:
+				details = "intercepts requests in kube-system"
+				if w.FailurePolicy == "Fail" {
+					finding.Severity = SeverityHigh
+					details += " and fails closed, which can block control plane components when it is down"
+				}
+			case WebhookLongTimeout:
+				finding.Severity = SeverityLow
+				details = fmt.Sprintf("timeout of %ds delays every matching request while the webhook is slow", w.TimeoutSeconds)
+			case WebhookCertExpired, WebhookCertExpiring:
+				verb := "expires"
+				if issue == WebhookCertExpired {
+					finding.Severity = SeverityHigh
+					verb = "expired"
+				}
+				var expiring []string
+				for _, c := range w.Certificates {
+					remaining := c.NotAfter.Sub(now)
+					if (issue == WebhookCertExpired) == (remaining < 0) && remaining < webhookExpiryWarning {
+						expiring = append(expiring, fmt.Sprintf("%s (%s)", c.Subject, c.NotAfter.Format("2006-01-02")))
+					}
+				}
+				details = fmt.Sprintf("caBundle certificate %s %s", verb, strings.Join(expiring, ", "))
+			case WebhookInvalidCABundle:
+				details = "caBundle could not be decoded"
+			}
+			finding.Details = fmt.Sprintf("Webhook %s: %s", w.Name, details)
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
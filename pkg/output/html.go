@@ -20,17 +20,81 @@ type HTMLData struct {
 	Title             string
 	GeneratedAt       string
 	ClusterName       string
+	Fingerprint       kubernetes.ClusterFingerprint
 	ResourceCounts    map[string]int
 	TotalResources    int
 	PrivilegedResults []kubernetes.PrivilegedContainer
 	CapabilityResults []kubernetes.CapabilityContainer
 	HostNSResults     []kubernetes.HostNamespaceWorkload
 	HostPathResults   []kubernetes.HostPathVolume
+	PSSResults        kubernetes.PSSResult
+	RBACResults       []kubernetes.SubjectPermissions
+	NetworkPolicies   kubernetes.NetworkPolicyResult
+	ExposureResults   []kubernetes.ExposureEntry
+	ResourceResults   kubernetes.ResourceResult
+	CapacityResults   kubernetes.CapacityReport
+	DeprecatedAPIs    []kubernetes.DeprecatedAPI
+	TargetVersion     string // Kubernetes version the deprecated API check targets
+	Reliability       kubernetes.ReliabilityResult
+	ServiceAccounts   []kubernetes.ServiceAccountToken
+	Webhooks          []kubernetes.AdmissionWebhook
+	Scheduling        []kubernetes.SchedulingEscape
+	Risk              kubernetes.RiskReport // weighted risk and hygiene scores of the checks' findings
+	Checks            []CheckResult // every registered analyzer, for the summary
+	ExtraChecks       []CheckResult // analyzers without a dedicated tab, shown generically
+}
+
+// CheckResult holds the findings of one registered analyzer
+type CheckResult struct {
+	ID          string
+	Name        string
+	Description string
+	Severity    kubernetes.Severity
+	Findings    []kubernetes.Finding
+}
+
+// dedicatedTabs lists the analyzers that have their own detailed tab in the report
+var dedicatedTabs = map[string]bool{
+	kubernetes.CheckPrivileged:      true,
+	kubernetes.CheckCapabilities:    true,
+	kubernetes.CheckHostNamespaces:  true,
+	kubernetes.CheckHostPath:        true,
+	kubernetes.CheckPSS:             true,
+	kubernetes.CheckRBAC:            true,
+	kubernetes.CheckNetworkPolicy:   true,
+	kubernetes.CheckExposure:        true,
+	kubernetes.CheckResources:       true,
+	kubernetes.CheckDeprecatedAPIs:  true,
+	kubernetes.CheckReliability:     true,
+	kubernetes.CheckServiceAccounts: true,
+	kubernetes.CheckWebhooks:        true,
+	kubernetes.CheckScheduling:      true,
+}
+
+// NewCheckResults pairs findings keyed by analyzer ID with the registered analyzers,
+// in registration order
+func NewCheckResults(findings map[string][]kubernetes.Finding) []CheckResult {
+	var results []CheckResult
+	for _, analyzer := range kubernetes.Analyzers() {
+		results = append(results, CheckResult{
+			ID:          analyzer.ID(),
+			Name:        analyzer.Name(),
+			Description: analyzer.Description(),
+			Severity:    analyzer.Severity(),
+			Findings:    findings[analyzer.ID()],
+		})
+	}
+	return results
 }
 
 // NewHTMLFormatter creates a new HTML formatter with the embedded template
 func NewHTMLFormatter() (*HTMLFormatter, error) {
-	tmpl, err := template.New("html").Parse(htmlTemplate)
+	tmpl, err := template.New("html").Funcs(template.FuncMap{
+		"formatRule":   kubernetes.FormatRule,
+		"formatCPU":    kubernetes.FormatCPU,
+		"formatMemory": kubernetes.FormatMemory,
+		"formatRatio":  kubernetes.FormatRatio,
+	}).Parse(htmlTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML template: %w", err)
 	}
@@ -40,32 +104,26 @@ func NewHTMLFormatter() (*HTMLFormatter, error) {
 	}, nil
 }
 
-// GenerateHTML creates HTML content from analysis results
-func (f *HTMLFormatter) GenerateHTML(
-	clusterName string,
-	resourceCounts map[string]int,
-	privilegedResults []kubernetes.PrivilegedContainer,
-	capabilityResults []kubernetes.CapabilityContainer,
-	hostNSResults []kubernetes.HostNamespaceWorkload,
-	hostPathResults []kubernetes.HostPathVolume,
-) ([]byte, error) {
-	// Calculate total resources
-	totalResources := 0
-	for _, count := range resourceCounts {
-		totalResources += count
+// GenerateHTML creates HTML content from analysis results. Title, GeneratedAt and
+// TotalResources are filled in when not already set.
+func (f *HTMLFormatter) GenerateHTML(data HTMLData) ([]byte, error) {
+	if data.Title == "" {
+		data.Title = "Eolas Kubernetes Analysis Report"
+	}
+	if data.GeneratedAt == "" {
+		data.GeneratedAt = time.Now().Format(time.RFC1123)
+	}
+	if data.TotalResources == 0 {
+		for _, count := range data.ResourceCounts {
+			data.TotalResources += count
+		}
 	}
 
-	// Prepare data for template
-	data := HTMLData{
-		Title:             "Eolas Kubernetes Analysis Report",
-		GeneratedAt:       time.Now().Format(time.RFC1123),
-		ClusterName:       clusterName,
-		ResourceCounts:    resourceCounts,
-		TotalResources:    totalResources,
-		PrivilegedResults: privilegedResults,
-		CapabilityResults: capabilityResults,
-		HostNSResults:     hostNSResults,
-		HostPathResults:   hostPathResults,
+	data.ExtraChecks = nil
+	for _, check := range data.Checks {
+		if !dedicatedTabs[check.ID] {
+			data.ExtraChecks = append(data.ExtraChecks, check)
+		}
 	}
 
 	var buf bytes.Buffer
@@ -312,6 +370,27 @@ const htmlTemplate = `<!DOCTYPE html>
             background-color: #f8d7da;
             border-left-color: #dc3545;
         }
+        
+        .severity-critical {
+            background-color: #8e44ad;
+        }
+        
+        .severity-high {
+            background-color: var(--warning-color);
+        }
+        
+        .severity-medium {
+            background-color: #e67e22;
+        }
+        
+        .severity-low {
+            background-color: #f1c40f;
+            color: var(--text-color);
+        }
+        
+        .severity-info {
+            background-color: var(--info-color);
+        }
     </style>
 </head>
 <body>
@@ -320,6 +399,12 @@ const htmlTemplate = `<!DOCTYPE html>
         
         <div class="report-meta">
             <p><strong>Cluster:</strong> {{ .ClusterName }}</p>
+            {{ if .Fingerprint.Distribution }}
+            <p><strong>Distribution:</strong> {{ .Fingerprint.Distribution }}{{ if .Fingerprint.KubernetesVersion }} {{ .Fingerprint.KubernetesVersion }}{{ end }}{{ if .Fingerprint.Evidence }} <small>(detected from {{ range $i, $e := .Fingerprint.Evidence }}{{ if $i }}, {{ end }}{{ $e }}{{ end }})</small>{{ end }}</p>
+            {{ range .Fingerprint.NodeSoftware }}
+            <p><strong>{{ .Count }} {{ if eq .Count 1 }}node{{ else }}nodes{{ end }}:</strong> kubelet {{ .KubeletVersion }}, {{ .ContainerRuntime }}, {{ .OSImage }}, kernel {{ .KernelVersion }}</p>
+            {{ end }}
+            {{ end }}
             <p><strong>Generated at:</strong> {{ .GeneratedAt }}</p>
             <p><strong>Total Resources:</strong> {{ .TotalResources }}</p>
         </div>
@@ -330,18 +415,104 @@ const htmlTemplate = `<!DOCTYPE html>
             <div class="tab" onclick="showTab('capabilities')">Linux Capabilities</div>
             <div class="tab" onclick="showTab('host-namespaces')">Host Namespaces</div>
             <div class="tab" onclick="showTab('host-paths')">Host Path Volumes</div>
+            <div class="tab" onclick="showTab('pss')">Pod Security Standards</div>
+            <div class="tab" onclick="showTab('rbac')">RBAC Privileges</div>
+            <div class="tab" onclick="showTab('network-policies')">Network Policies</div>
+            <div class="tab" onclick="showTab('exposure')">External Exposure</div>
+            <div class="tab" onclick="showTab('resources')">Resources &amp; QoS</div>
+            <div class="tab" onclick="showTab('capacity')">Node Capacity</div>
+            <div class="tab" onclick="showTab('deprecated-apis')">Deprecated APIs</div>
+            <div class="tab" onclick="showTab('reliability')">Reliability</div>
+            <div class="tab" onclick="showTab('service-accounts')">Service Account Tokens</div>
+            <div class="tab" onclick="showTab('webhooks')">Admission Webhooks</div>
+            <div class="tab" onclick="showTab('scheduling')">Scheduling Escape</div>
+            {{ range .ExtraChecks }}
+            <div class="tab" onclick="showTab('check-{{ .ID }}')">{{ .Name }}</div>
+            {{ end }}
         </div>
 
         <!-- Overview Tab Content -->
         <div id="overview" class="tab-content active">
             <h2>Overview</h2>
             
+            <div class="summary-box">
+                <h3>Risk Score</h3>
+                <p><strong>Cluster risk score: {{ .Risk.Cluster.Score }}</strong> from {{ .Risk.Cluster.Findings }} findings:
+                    <span class="badge severity-critical">{{ .Risk.Cluster.Critical }} critical</span>
+                    <span class="badge severity-high">{{ .Risk.Cluster.High }} high</span>
+                    <span class="badge severity-medium">{{ .Risk.Cluster.Medium }} medium</span>
+                    <span class="badge severity-low">{{ .Risk.Cluster.Low }} low</span>
+                </p>
+                <p>Hygiene score: {{ .Risk.Hygiene.Score }} from {{ .Risk.Hygiene.Findings }} findings of the image, resource, deprecated API, reliability and reference checks</p>
+                <p><small>Each security finding adds 10 (critical), 5 (high), 2 (medium) or 1 (low) to the score of its workload, its namespace and the cluster. Hygiene findings only add to the hygiene score.</small></p>
+            </div>
+            
+            {{ if .Risk.Namespaces }}
+            <h3>Namespaces by Risk</h3>
+            <table class="data-table">
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Score</th>
+                        <th>Findings</th>
+                        <th>Critical</th>
+                        <th>High</th>
+                        <th>Medium</th>
+                        <th>Low</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .Risk.Namespaces }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td><strong>{{ .Score }}</strong></td>
+                        <td>{{ .Findings }}</td>
+                        <td>{{ .Critical }}</td>
+                        <td>{{ .High }}</td>
+                        <td>{{ .Medium }}</td>
+                        <td>{{ .Low }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            {{ end }}
+            
+            {{ if .Risk.Workloads }}
+            <h3>Highest Risk Workloads</h3>
+            <table class="data-table">
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Kind</th>
+                        <th>Name</th>
+                        <th>Score</th>
+                        <th>Findings</th>
+                        <th>Critical</th>
+                        <th>High</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range $i, $w := .Risk.Workloads }}{{ if lt $i 20 }}
+                    <tr>
+                        <td>{{ if $w.Namespace }}{{ $w.Namespace }}{{ else }}-{{ end }}</td>
+                        <td>{{ $w.Kind }}</td>
+                        <td>{{ $w.Name }}</td>
+                        <td><strong>{{ $w.Score }}</strong></td>
+                        <td>{{ $w.Findings }}</td>
+                        <td>{{ $w.Critical }}</td>
+                        <td>{{ $w.High }}</td>
+                    </tr>
+                    {{ end }}{{ end }}
+                </tbody>
+            </table>
+            {{ end }}
+            
             <div class="summary-box">
                 <h3>Security Findings Summary</h3>
                 <ul>
-                    <li><strong>Privileged Containers:</strong> {{ len .PrivilegedResults }}</li>
-                    <li><strong>Containers with Added Capabilities:</strong> {{ len .CapabilityResults }}</li>
-                    <li><strong>Workloads Using Host Namespaces:</strong> {{ len .HostNSResults }}</li>
+                    {{ range .Checks }}
+                    <li><span class="badge severity-{{ .Severity }}">{{ .Severity }}</span> <strong>{{ .Name }}:</strong> {{ len .Findings }}</li>
+                    {{ end }}
                 </ul>
             </div>
             
@@ -374,6 +545,8 @@ const htmlTemplate = `<!DOCTYPE html>
                         <th>Resource Type</th>
                         <th>Resource Name</th>
                         <th>Container Name</th>
+                        <th>Type</th>
+                        <th>Effective Security Context</th>
                     </tr>
                 </thead>
                 <tbody>
@@ -383,6 +556,8 @@ const htmlTemplate = `<!DOCTYPE html>
                         <td>{{ .Kind }}</td>
                         <td>{{ .PodName }}</td>
                         <td>{{ .Name }}</td>
+                        <td>{{ .Type }}</td>
+                        <td>{{ range .Settings }}{{ . }}<br>{{ end }}</td>
                     </tr>
                     {{ end }}
                 </tbody>
@@ -409,7 +584,9 @@ const htmlTemplate = `<!DOCTYPE html>
                         <th>Resource Type</th>
                         <th>Resource Name</th>
                         <th>Container</th>
+                        <th>Type</th>
                         <th>Capabilities</th>
+                        <th>Effective Security Context</th>
                     </tr>
                 </thead>
                 <tbody>
@@ -419,11 +596,13 @@ const htmlTemplate = `<!DOCTYPE html>
                         <td>{{ .Kind }}</td>
                         <td>{{ .PodName }}</td>
                         <td>{{ .Name }}</td>
+                        <td>{{ .Type }}</td>
                         <td>
                             {{ range .Capabilities }}
                             <span class="badge {{ if or (eq . "CAP_SYS_ADMIN") (eq . "CAP_NET_ADMIN") (eq . "CAP_SYS_PTRACE") (eq . "CAP_NET_RAW") (eq . "NET_ADMIN") (eq . "SYS_ADMIN") (eq . "SYS_PTRACE") (eq . "NET_RAW") }}badge-true{{ else }}badge-false{{ end }}">{{ . }}</span>
                             {{ end }}
                         </td>
+                        <td>{{ range .Settings }}{{ . }}<br>{{ end }}</td>
                     </tr>
                     {{ end }}
                 </tbody>
@@ -507,7 +686,9 @@ const htmlTemplate = `<!DOCTYPE html>
                         <th>Resource Type</th>
                         <th>Name</th>
                         <th>Host Path</th>
+                        <th>Severity</th>
                         <th>Read-Only</th>
+                        <th>Reason</th>
                     </tr>
                 </thead>
                 <tbody>
@@ -528,6 +709,7 @@ const htmlTemplate = `<!DOCTYPE html>
                                 <td></td>
                                 {{ end }}
                                 <td>{{ $path }}</td>
+                                <td>{{ with index $volume.Severities $i }}<span class="badge severity-{{ . }}">{{ . }}</span>{{ end }}</td>
                                 <td>
                                     {{ if and (ge $i 0) (lt $i (len $volume.ReadOnly)) }}
                                         {{ if index $volume.ReadOnly $i }}Yes{{ else }}No{{ end }}
@@ -535,6 +717,7 @@ const htmlTemplate = `<!DOCTYPE html>
                                         Unknown
                                     {{ end }}
                                 </td>
+                                <td>{{ index $volume.Reasons $i }}</td>
                             </tr>
                         {{ end }}
                     {{ end }}
@@ -559,6 +742,800 @@ const htmlTemplate = `<!DOCTYPE html>
             {{ end }}
         </div>
 
+        <!-- Pod Security Standards Tab Content -->
+        <div id="pss" class="tab-content">
+            <h2>Pod Security Standards</h2>
+            
+            {{ if .PSSResults.Namespaces }}
+            <p>The highest Pod Security Standards profile each namespace could enforce without rejecting any of its current workloads.</p>
+            
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Workloads</th>
+                        <th>Currently Enforced</th>
+                        <th>Enforceable Profile</th>
+                        <th>Failing Baseline</th>
+                        <th>Failing Restricted</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .PSSResults.Namespaces }}
+                    <tr>
+                        <td>{{ if .Namespace }}{{ .Namespace }}{{ else }}default{{ end }}</td>
+                        <td>{{ .Workloads }}</td>
+                        <td>{{ if .CurrentEnforce }}{{ .CurrentEnforce }}{{ else }}-{{ end }}</td>
+                        <td><span class="badge {{ if eq .Level "restricted" }}badge-false{{ else if eq .Level "baseline" }}severity-medium{{ else }}badge-true{{ end }}">{{ .Level }}</span></td>
+                        <td>{{ .BaselineViolations }}</td>
+                        <td>{{ .RestrictedViolations }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <h3>Workload Violations</h3>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Resource Type</th>
+                        <th>Name</th>
+                        <th>Profile</th>
+                        <th>Container</th>
+                        <th>Check</th>
+                        <th>Details</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .PSSResults.Workloads }}
+                        {{ $workload := . }}
+                        {{ range $i, $v := .Violations }}
+                        <tr>
+                            {{ if eq $i 0 }}
+                            <td>{{ if $workload.Namespace }}{{ $workload.Namespace }}{{ else }}default{{ end }}</td>
+                            <td>{{ $workload.Kind }}</td>
+                            <td>{{ $workload.Name }}</td>
+                            {{ else }}
+                            <td></td>
+                            <td></td>
+                            <td></td>
+                            {{ end }}
+                            <td><span class="badge {{ if eq $v.Level "baseline" }}badge-true{{ else }}severity-medium{{ end }}">{{ $v.Level }}</span></td>
+                            <td>{{ if $v.Container }}{{ $v.Container }}{{ else }}-{{ end }}</td>
+                            <td>{{ $v.Check }}</td>
+                            <td>{{ $v.Detail }}</td>
+                        </tr>
+                        {{ end }}
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <div class="note">
+                <p>A namespace can be moved to its enforceable profile with the <code>pod-security.kubernetes.io/enforce</code> label. The Profile column shows the lowest profile that rejects each setting.</p>
+            </div>
+            {{ else }}
+            <p>No namespaces or workloads found in the cluster.</p>
+            {{ end }}
+        </div>
+
+        <!-- RBAC Privileges Tab Content -->
+        <div id="rbac" class="tab-content">
+            <h2>RBAC Privileges</h2>
+            
+            {{ if .RBACResults }}
+            <p>Effective permissions of each user, group and service account, resolved through RoleBindings and ClusterRoleBindings. Default bootstrap bindings are not shown.</p>
+            
+            <table>
+                <thead>
+                    <tr>
+                        <th>Subject Type</th>
+                        <th>Subject</th>
+                        <th>Binding</th>
+                        <th>Role</th>
+                        <th>Scope</th>
+                        <th>Rules</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .RBACResults }}
+                        {{ $subject := . }}
+                        {{ range $i, $g := .Grants }}
+                        <tr>
+                            {{ if eq $i 0 }}
+                            <td>{{ $subject.Kind }}</td>
+                            <td>{{ if $subject.Namespace }}{{ $subject.Namespace }}/{{ end }}{{ $subject.Name }}{{ if $subject.ClusterAdmin }} <span class="badge severity-critical">cluster admin</span>{{ end }}</td>
+                            {{ else }}
+                            <td></td>
+                            <td></td>
+                            {{ end }}
+                            <td>{{ $g.Binding }}</td>
+                            <td>{{ $g.Role }}</td>
+                            <td>{{ if $g.Namespace }}{{ $g.Namespace }}{{ else }}cluster-wide{{ end }}</td>
+                            <td>{{ if $g.Missing }}<em>role not found</em>{{ else }}{{ range $g.Rules }}{{ formatRule . }}<br>{{ end }}{{ end }}</td>
+                        </tr>
+                        {{ end }}
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <h3>Dangerous Permissions</h3>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Severity</th>
+                        <th>Subject Type</th>
+                        <th>Subject</th>
+                        <th>Check</th>
+                        <th>Scope</th>
+                        <th>Details</th>
+                        <th>Binding</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .RBACResults }}
+                        {{ $subject := . }}
+                        {{ range .Risks }}
+                        <tr>
+                            <td><span class="badge severity-{{ .Severity }}">{{ .Severity }}</span></td>
+                            <td>{{ $subject.Kind }}</td>
+                            <td>{{ if $subject.Namespace }}{{ $subject.Namespace }}/{{ end }}{{ $subject.Name }}</td>
+                            <td>{{ .Check }}</td>
+                            <td>{{ if .Namespace }}{{ .Namespace }}{{ else }}cluster-wide{{ end }}</td>
+                            <td>{{ .Detail }}</td>
+                            <td>{{ .Binding }} &rarr; {{ .Role }}</td>
+                        </tr>
+                        {{ end }}
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <div class="note">
+                <p>Permissions such as escalate, bind, impersonate, create on pods/exec, reading secrets and nodes/proxy can each be used to gain further access to the cluster.</p>
+            </div>
+            {{ else }}
+            <p>No role bindings found in the cluster.</p>
+            {{ end }}
+        </div>
+
+        <!-- Network Policies Tab Content -->
+        <div id="network-policies" class="tab-content">
+            <h2>Network Policy Coverage</h2>
+            
+            {{ if .NetworkPolicies.Namespaces }}
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Policies</th>
+                        <th>Workloads</th>
+                        <th>Default-Deny Ingress</th>
+                        <th>Default-Deny Egress</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .NetworkPolicies.Namespaces }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td>{{ .Policies }}</td>
+                        <td>{{ .Workloads }}</td>
+                        <td><span class="badge {{ if .DefaultDenyIngress }}badge-false{{ else }}badge-true{{ end }}">{{ .DefaultDenyIngress }}</span></td>
+                        <td><span class="badge {{ if .DefaultDenyEgress }}badge-false{{ else }}badge-true{{ end }}">{{ .DefaultDenyEgress }}</span></td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <h3>Workloads Not Selected by Any Policy</h3>
+            {{ if .NetworkPolicies.UnselectedWorkloads }}
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Resource Type</th>
+                        <th>Name</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .NetworkPolicies.UnselectedWorkloads }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ .Name }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            {{ else }}
+            <p>Every workload is selected by at least one NetworkPolicy.</p>
+            {{ end }}
+            
+            <h3>Workloads Reachable from All Namespaces</h3>
+            {{ if .NetworkPolicies.OpenWorkloads }}
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Resource Type</th>
+                        <th>Name</th>
+                        <th>Policies</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .NetworkPolicies.OpenWorkloads }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ range $i, $p := .Policies }}{{ if $i }}, {{ end }}{{ $p }}{{ end }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            {{ else }}
+            <p>No isolated workload admits traffic from all namespaces.</p>
+            {{ end }}
+            
+            {{ if .NetworkPolicies.UnusedPolicies }}
+            <h3>Policies Matching No Workload</h3>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Policy</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .NetworkPolicies.UnusedPolicies }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td>{{ .Name }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            {{ end }}
+            
+            <div class="note">
+                <p>Pods not selected by any NetworkPolicy accept traffic from every pod in the cluster, which allows lateral movement after a single workload is compromised. A policy with an empty podSelector and no rules denies all ingress or egress traffic in its namespace by default.</p>
+            </div>
+            {{ else }}
+            <p>No namespaces or workloads found in the cluster.</p>
+            {{ end }}
+        </div>
+
+        <!-- External Exposure Tab Content -->
+        <div id="exposure" class="tab-content">
+            <h2>External Exposure</h2>
+            
+            {{ if .ExposureResults }}
+            <p>Found {{ len .ExposureResults }} entry points reachable from outside the cluster. Entry points whose workloads also have security findings are listed first.</p>
+            
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Kind</th>
+                        <th>Name</th>
+                        <th>Type</th>
+                        <th>Hosts</th>
+                        <th>Ports</th>
+                        <th>TLS</th>
+                        <th>Workloads</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .ExposureResults }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ .Type }}</td>
+                        <td>{{ range .Hosts }}{{ . }}<br>{{ end }}</td>
+                        <td>{{ range .Ports }}{{ . }}<br>{{ end }}</td>
+                        <td>{{ if or (eq .Type "Ingress") (eq .Type "HTTPRoute") }}<span class="badge {{ if .TLS }}badge-false{{ else }}badge-true{{ end }}">{{ if .TLS }}Yes{{ else }}No{{ end }}</span>{{ else }}-{{ end }}</td>
+                        <td>
+                            {{ range .Workloads }}
+                            {{ .Kind }}/{{ .Name }}{{ range .Findings }} <span class="badge severity-high">{{ . }}</span>{{ end }}<br>
+                            {{ else }}-{{ end }}
+                        </td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <div class="note">
+                <p>Exposed workloads that are also privileged, add capabilities, share host namespaces or mount hostPath volumes give an external attacker a direct route to the node and should be reviewed first.</p>
+            </div>
+            {{ else }}
+            <p>No NodePort, LoadBalancer or externalIPs Services, Ingresses or HTTPRoutes found in the cluster.</p>
+            {{ end }}
+        </div>
+
+        <!-- Resources & QoS Tab Content -->
+        <div id="resources" class="tab-content">
+            <h2>Resource Requests and Limits</h2>
+            
+            {{ if .ResourceResults.Workloads }}
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Workloads</th>
+                        <th>Containers</th>
+                        <th>No Requests</th>
+                        <th>No Limits</th>
+                        <th>High Limits</th>
+                        <th>Guaranteed</th>
+                        <th>Burstable</th>
+                        <th>BestEffort</th>
+                        <th>LimitRange</th>
+                        <th>ResourceQuota</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .ResourceResults.Namespaces }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td>{{ .Workloads }}</td>
+                        <td>{{ .Containers }}</td>
+                        <td>{{ .MissingRequests }}</td>
+                        <td>{{ .MissingLimits }}</td>
+                        <td>{{ .HighLimits }}</td>
+                        <td>{{ .Guaranteed }}</td>
+                        <td>{{ .Burstable }}</td>
+                        <td>{{ .BestEffort }}</td>
+                        <td><span class="badge {{ if .HasLimitRange }}badge-false{{ else }}badge-true{{ end }}">{{ if .HasLimitRange }}Yes{{ else }}No{{ end }}</span></td>
+                        <td><span class="badge {{ if .HasResourceQuota }}badge-false{{ else }}badge-true{{ end }}">{{ if .HasResourceQuota }}Yes{{ else }}No{{ end }}</span></td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <h3>Workloads</h3>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Resource Type</th>
+                        <th>Name</th>
+                        <th>QoS Class</th>
+                        <th>Container</th>
+                        <th>CPU Request / Limit</th>
+                        <th>Memory Request / Limit</th>
+                        <th>Issues</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .ResourceResults.Workloads }}
+                        {{ $workload := . }}
+                        {{ range $i, $c := .Containers }}
+                        <tr>
+                            {{ if eq $i 0 }}
+                            <td>{{ $workload.Namespace }}</td>
+                            <td>{{ $workload.Kind }}</td>
+                            <td>{{ $workload.Name }}</td>
+                            <td><span class="badge {{ if eq $workload.QoSClass "BestEffort" }}badge-true{{ else if eq $workload.QoSClass "Burstable" }}severity-medium{{ else }}badge-false{{ end }}">{{ $workload.QoSClass }}</span></td>
+                            {{ else }}
+                            <td></td>
+                            <td></td>
+                            <td></td>
+                            <td></td>
+                            {{ end }}
+                            <td>{{ $c.Name }}</td>
+                            <td>{{ if $c.CPURequest }}{{ $c.CPURequest }}{{ else }}-{{ end }} / {{ if $c.CPULimit }}{{ $c.CPULimit }}{{ else }}-{{ end }}</td>
+                            <td>{{ if $c.MemoryRequest }}{{ $c.MemoryRequest }}{{ else }}-{{ end }} / {{ if $c.MemoryLimit }}{{ $c.MemoryLimit }}{{ else }}-{{ end }}</td>
+                            <td>{{ if $c.Missing }}missing {{ range $j, $m := $c.Missing }}{{ if $j }}, {{ end }}{{ $m }}{{ end }}<br>{{ end }}{{ range $c.HighLimits }}{{ . }}<br>{{ end }}</td>
+                        </tr>
+                        {{ end }}
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <div class="note">
+                <p>Containers without requests can be scheduled onto nodes without capacity for them, and BestEffort pods are evicted first under node pressure. A LimitRange sets default requests and limits for a namespace and a ResourceQuota caps its total usage.</p>
+            </div>
+            {{ else }}
+            <p>No workloads found in the cluster.</p>
+            {{ end }}
+        </div>
+
+        <!-- Node Capacity Tab Content -->
+        <div id="capacity" class="tab-content">
+            <h2>Node Capacity</h2>
+            
+            {{ if .CapacityResults.Nodes }}
+            <h3>Node Pools</h3>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Pool</th>
+                        <th>Nodes</th>
+                        <th>Pods</th>
+                        <th>CPU Requests / Allocatable</th>
+                        <th>CPU Limits</th>
+                        <th>Memory Requests / Allocatable</th>
+                        <th>Memory Limits</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .CapacityResults.Pools }}
+                    <tr>
+                        <td>{{ .Name }}</td>
+                        <td>{{ .Nodes }}</td>
+                        <td>{{ .Pods }}</td>
+                        <td>{{ formatCPU .CPURequests }} / {{ formatCPU .CPUAllocatable }} ({{ formatRatio .CPURequestRatio }})</td>
+                        <td><span class="badge {{ if gt .CPULimitRatio 1.0 }}badge-true{{ else }}badge-false{{ end }}">{{ formatCPU .CPULimits }} ({{ formatRatio .CPULimitRatio }})</span></td>
+                        <td>{{ formatMemory .MemoryRequests }} / {{ formatMemory .MemoryAllocatable }} ({{ formatRatio .MemoryRequestRatio }})</td>
+                        <td><span class="badge {{ if gt .MemoryLimitRatio 1.0 }}badge-true{{ else }}badge-false{{ end }}">{{ formatMemory .MemoryLimits }} ({{ formatRatio .MemoryLimitRatio }})</span></td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <h3>Nodes</h3>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Node</th>
+                        <th>Pool</th>
+                        <th>Pods</th>
+                        <th>CPU Requests / Allocatable</th>
+                        <th>CPU Limits</th>
+                        <th>Memory Requests / Allocatable</th>
+                        <th>Memory Limits</th>
+                        <th>Taints</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .CapacityResults.Nodes }}
+                    <tr>
+                        <td>{{ .Name }}{{ if .Unschedulable }} <span class="badge badge-true">cordoned</span>{{ end }}</td>
+                        <td>{{ .Pool }}</td>
+                        <td>{{ .Pods }}</td>
+                        <td>{{ formatCPU .CPURequests }} / {{ formatCPU .CPUAllocatable }} ({{ formatRatio .CPURequestRatio }})</td>
+                        <td><span class="badge {{ if gt .CPULimitRatio 1.0 }}badge-true{{ else }}badge-false{{ end }}">{{ formatCPU .CPULimits }} ({{ formatRatio .CPULimitRatio }})</span></td>
+                        <td>{{ formatMemory .MemoryRequests }} / {{ formatMemory .MemoryAllocatable }} ({{ formatRatio .MemoryRequestRatio }})</td>
+                        <td><span class="badge {{ if gt .MemoryLimitRatio 1.0 }}badge-true{{ else }}badge-false{{ end }}">{{ formatMemory .MemoryLimits }} ({{ formatRatio .MemoryLimitRatio }})</span></td>
+                        <td>{{ range .Taints }}{{ . }}<br>{{ end }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            {{ if .CapacityResults.Unschedulable }}
+            <h3>Unschedulable Workloads</h3>
+            <div class="alert alert-warning">
+                <p><strong>Found {{ len .CapacityResults.Unschedulable }} workloads that cannot schedule on any node.</strong></p>
+            </div>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Resource Type</th>
+                        <th>Name</th>
+                        <th>Reason</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .CapacityResults.Unschedulable }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ .Reason }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            {{ end }}
+            
+            <div class="note">
+                <p>Limits above 100% of allocatable mean a node is overcommitted: pods may be CPU throttled or OOM killed when they use more than they requested.</p>
+            </div>
+            {{ else }}
+            <p>No Node objects found in the configuration.</p>
+            {{ end }}
+        </div>
+
+        <!-- Deprecated APIs Tab Content -->
+        <div id="deprecated-apis" class="tab-content">
+            <h2>Deprecated APIs</h2>
+            <p><strong>Target version:</strong> {{ if .TargetVersion }}{{ .TargetVersion }}{{ else }}any (all deprecations are listed){{ end }}</p>
+            
+            {{ if .DeprecatedAPIs }}
+            <div class="alert alert-warning">
+                <p><strong>Found {{ len .DeprecatedAPIs }} resources using deprecated API versions.</strong></p>
+            </div>
+            
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Resource Type</th>
+                        <th>Name</th>
+                        <th>API Version</th>
+                        <th>Deprecated In</th>
+                        <th>Removed In</th>
+                        <th>Replacement</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .DeprecatedAPIs }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ .APIVersion }}{{ if ne .Source "object" }} <em>(last applied)</em>{{ end }}</td>
+                        <td>{{ .DeprecatedIn }}</td>
+                        <td><span class="badge {{ if .Removed }}badge-true{{ else }}severity-medium{{ end }}">{{ .RemovedIn }}</span></td>
+                        <td>{{ .Replacement }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <div class="note">
+                <p>Resources whose removal version is highlighted in red use APIs the target version no longer serves. Versions marked "last applied" come from the kubectl.kubernetes.io/last-applied-configuration annotation: the manifests used to apply them must be updated before upgrading.</p>
+            </div>
+            {{ else }}
+            <p>No resources using deprecated API versions found in the cluster.</p>
+            {{ end }}
+        </div>
+
+        <!-- Reliability Tab Content -->
+        <div id="reliability" class="tab-content">
+            <h2>Reliability</h2>
+            
+            {{ if or .Reliability.Workloads .Reliability.BlockingPDBs }}
+            {{ if .Reliability.Workloads }}
+            <h3>Workloads</h3>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Resource Type</th>
+                        <th>Name</th>
+                        <th>Replicas</th>
+                        <th>PodDisruptionBudgets</th>
+                        <th>Issues</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .Reliability.Workloads }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ if eq .Kind "DaemonSet" }}-{{ else }}{{ .Replicas }}{{ end }}</td>
+                        <td>{{ range .PDBs }}{{ . }}<br>{{ else }}-{{ end }}</td>
+                        <td>
+                            {{ range .Issues }}
+                            <span class="badge {{ if eq . "single-replica-no-pdb" }}badge-true{{ else }}severity-medium{{ end }}">{{ . }}</span>
+                            {{ end }}
+                            {{ if .NoReadinessProbe }}<br>No readiness probe: {{ range $i, $c := .NoReadinessProbe }}{{ if $i }}, {{ end }}{{ $c }}{{ end }}{{ end }}
+                            {{ if .NoLivenessProbe }}<br>No liveness probe: {{ range $i, $c := .NoLivenessProbe }}{{ if $i }}, {{ end }}{{ $c }}{{ end }}{{ end }}
+                        </td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            {{ end }}
+            
+            {{ if .Reliability.BlockingPDBs }}
+            <h3>PodDisruptionBudgets Blocking Evictions</h3>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Name</th>
+                        <th>Reason</th>
+                        <th>Workloads</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .Reliability.BlockingPDBs }}
+                    <tr>
+                        <td>{{ .Namespace }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ .Reason }}</td>
+                        <td>{{ range .Workloads }}{{ . }}<br>{{ else }}-{{ end }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            {{ end }}
+            
+            <div class="note">
+                <p>A single replica without a PodDisruptionBudget goes down on every node drain, while a PodDisruptionBudget that allows no evictions stops drains and upgrades from completing. Probes let Kubernetes route traffic only to ready pods and restart hung ones, and spread constraints or anti-affinity keep replicas off the same node or zone.</p>
+            </div>
+            {{ else }}
+            <p>No reliability issues found in the cluster.</p>
+            {{ end }}
+        </div>
+
+        <!-- Service Account Tokens Tab Content -->
+        <div id="service-accounts" class="tab-content">
+            <h2>Service Account Tokens</h2>
+            
+            {{ if .ServiceAccounts }}
+            <div class="alert alert-warning">
+                <p><strong>Warning:</strong> Found {{ len .ServiceAccounts }} service account token issues.</p>
+                <p>An automounted token lets anyone who compromises a pod act with its service account's RBAC permissions, and long-lived token Secrets never expire.</p>
+            </div>
+            
+            <table class="data-table">
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Resource Type</th>
+                        <th>Name</th>
+                        <th>Service Account</th>
+                        <th>Issue</th>
+                        <th>Automount</th>
+                        <th>Bindings</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .ServiceAccounts }}
+                    <tr>
+                        <td>{{ if eq .Namespace "" }}default{{ else }}{{ .Namespace }}{{ end }}</td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ .ServiceAccount }}</td>
+                        <td><span class="badge {{ if eq .Issue "default-service-account" }}severity-low{{ else if .ClusterAdmin }}badge-true{{ else }}severity-medium{{ end }}">{{ .Issue }}</span></td>
+                        <td>{{ if .AutomountSource }}{{ .AutomountSource }}{{ else }}-{{ end }}</td>
+                        <td>
+                            {{ range .Bindings }}{{ . }}<br>{{ else }}-{{ end }}
+                            {{ if .ClusterAdmin }}<span class="badge badge-true">cluster-admin</span>{{ end }}
+                        </td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <div class="note">
+                <p>Set <code>automountServiceAccountToken: false</code> on pods or service accounts that do not call the Kubernetes API, give each workload its own service account, and replace <code>kubernetes.io/service-account-token</code> Secrets with short-lived projected tokens.</p>
+            </div>
+            {{ else }}
+            <p>No service account token exposure found in the cluster.</p>
+            {{ end }}
+        </div>
+
+        <!-- Admission Webhooks Tab Content -->
+        <div id="webhooks" class="tab-content">
+            <h2>Admission Webhooks</h2>
+            
+            {{ if .Webhooks }}
+            <table class="data-table">
+                <thead>
+                    <tr>
+                        <th>Type</th>
+                        <th>Configuration</th>
+                        <th>Webhook</th>
+                        <th>Endpoint</th>
+                        <th>Failure Policy</th>
+                        <th>Timeout</th>
+                        <th>CA Certificates</th>
+                        <th>Issues</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .Webhooks }}
+                    <tr>
+                        <td>{{ if eq .Kind "MutatingWebhookConfiguration" }}Mutating{{ else }}Validating{{ end }}</td>
+                        <td>{{ .Configuration }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ if .Endpoint }}{{ .Endpoint }}{{ else }}-{{ end }}</td>
+                        <td>{{ .FailurePolicy }}</td>
+                        <td>{{ .TimeoutSeconds }}s</td>
+                        <td>{{ range .Certificates }}{{ .Subject }}: expires {{ .NotAfter.Format "2006-01-02" }}<br>{{ else }}-{{ end }}</td>
+                        <td>
+                            {{ range .Issues }}
+                            <span class="badge {{ if or (eq . "fail-open") (eq . "ca-expired") }}badge-true{{ else }}severity-medium{{ end }}">{{ . }}</span>
+                            {{ else }}-{{ end }}
+                            {{ if .Exempted }}{{ if gt (len .Exempted) 1 }}<br>Exempts: {{ range $i, $ns := .Exempted }}{{ if $i }}, {{ end }}{{ $ns }}{{ end }}{{ end }}{{ end }}
+                        </td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <div class="note">
+                <p>Security webhooks with <code>failurePolicy: Ignore</code> admit every request while they are unavailable. Webhooks that intercept kube-system and fail closed can stop control plane components from being recreated, and an expired caBundle makes every call to the webhook fail.</p>
+            </div>
+            {{ else }}
+            <p>No admission webhooks found in the cluster.</p>
+            {{ end }}
+        </div>
+
+        <!-- Scheduling Escape Tab Content -->
+        <div id="scheduling" class="tab-content">
+            <h2>Scheduling Escape</h2>
+            
+            {{ if .Scheduling }}
+            <div class="alert alert-warning">
+                <p><strong>Warning:</strong> Found {{ len .Scheduling }} workloads able to schedule onto control plane nodes.</p>
+                <p>A workload that reaches a control plane node and can also take over its node, through a privileged container, hostPID or a writable hostPath, can read etcd data and control plane keys.</p>
+            </div>
+            
+            <table class="data-table">
+                <thead>
+                    <tr>
+                        <th>Namespace</th>
+                        <th>Resource Type</th>
+                        <th>Name</th>
+                        <th>Evidence</th>
+                        <th>Confirmed</th>
+                        <th>Control Plane Nodes</th>
+                        <th>Node Takeover</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .Scheduling }}
+                    <tr>
+                        <td>{{ if .Namespace }}{{ .Namespace }}{{ else }}default{{ end }}</td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ range .Evidence }}{{ . }}<br>{{ end }}</td>
+                        <td><span class="badge {{ if eq .Confirmation "confirmed" }}badge-true{{ else if eq .Confirmation "unverified" }}severity-medium{{ else }}severity-low{{ end }}">{{ .Confirmation }}</span></td>
+                        <td>{{ range .ControlPlaneNodes }}{{ . }}<br>{{ else }}-{{ end }}</td>
+                        <td>{{ range .HostEscapes }}{{ . }}<br>{{ else }}-{{ end }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            
+            <div class="note">
+                <p>Confirmation uses the control plane Nodes in the snapshot: <em>confirmed</em> workloads can run on at least one, <em>not-matched</em> workloads are admitted by none and <em>unverified</em> snapshots have no control plane nodes, as in most managed clusters.</p>
+            </div>
+            {{ else }}
+            <p>No workloads able to schedule onto control plane nodes found in the cluster. 👍</p>
+            {{ end }}
+        </div>
+
+        <!-- Generic tabs for analyzers without a dedicated view -->
+        {{ range .ExtraChecks }}
+        <div id="check-{{ .ID }}" class="tab-content">
+            <h2>{{ .Name }}</h2>
+            
+            {{ if .Findings }}
+            <div class="alert {{ if or (eq .Severity "critical") (eq .Severity "high") }}alert-danger{{ else }}alert-warning{{ end }}">
+                <p><strong>Found {{ len .Findings }} findings.</strong></p>
+                <p>{{ .Description }}</p>
+            </div>
+            
+            <table>
+                <thead>
+                    <tr>
+                        <th>Severity</th>
+                        <th>Namespace</th>
+                        <th>Resource Type</th>
+                        <th>Resource Name</th>
+                        <th>Container</th>
+                        <th>Details</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{ range .Findings }}
+                    <tr>
+                        <td><span class="badge severity-{{ .Severity }}">{{ .Severity }}</span></td>
+                        <td>{{ if .Namespace }}{{ .Namespace }}{{ else }}default{{ end }}</td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ if .Container }}{{ .Container }}{{ else }}-{{ end }}</td>
+                        <td>{{ .Details }}</td>
+                    </tr>
+                    {{ end }}
+                </tbody>
+            </table>
+            {{ else }}
+            <p>{{ .Description }}</p>
+            <p>No findings. 👍</p>
+            {{ end }}
+        </div>
+        {{ end }}
+
         <div class="footer">
             <p>Generated by Eolas - Kubernetes Cluster Analyzer</p>
             <p><a href="https://github.com/raesene/eolas" target="_blank">GitHub Repository</a></p>
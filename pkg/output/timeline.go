@@ -7,6 +7,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/raesene/eolas/pkg/kubernetes"
 	"github.com/raesene/eolas/pkg/storage"
 )
 
@@ -25,6 +26,10 @@ type TimelineData struct {
 	Timeline         []TimelineEntry
 	ResourceTrends   []ResourceTrend
 	SecurityTrends   []SecurityTrend
+	RiskTrends       []SecurityTrend // risk score of each namespace whose score changed
+	Remediation      []storage.RemediationStats
+	ResolvedFindings []LifecycleRow // most recently resolved first
+	OpenFindings     []LifecycleRow // longest open first
 	CurrentSnapshot  SnapshotData
 	PreviousSnapshot *SnapshotData
 }
@@ -36,11 +41,25 @@ type TimelineEntry struct {
 	FormattedTime    string
 	TotalResources   int
 	SecurityIssues   int
+	RiskScore        int
 	ResourceChanges  map[string]int
 	SecurityChanges  map[string]int
+	Introduced       int // findings first seen in this version
+	Resolved         int // findings this version no longer has
 	IsLatest         bool
 }
 
+// LifecycleRow is a finding in the lifecycle tables of the report
+type LifecycleRow struct {
+	Severity   string
+	Check      string
+	Subject    string
+	Details    string
+	FirstSeen  string
+	ResolvedAt string
+	Age        string // time to resolve, or how long it has been open
+}
+
 // ResourceTrend represents how a resource type has changed over time
 type ResourceTrend struct {
 	ResourceType string
@@ -75,12 +94,14 @@ type SnapshotData struct {
 	HostNamespaceUsage    int
 	HostPathVolumes       int
 	TotalSecurityIssues   int
+	Risk                  kubernetes.RiskScore
 }
 
 // NewTimelineFormatter creates a new timeline HTML formatter
 func NewTimelineFormatter() (*TimelineFormatter, error) {
 	tmpl, err := template.New("timeline").Funcs(template.FuncMap{
 		"add": func(a, b int) int { return a + b },
+		"duration": FormatDuration,
 		"formatChange": func(change int) string {
 			if change > 0 {
 				return fmt.Sprintf("+%d", change)
@@ -117,7 +138,7 @@ func NewTimelineFormatter() (*TimelineFormatter, error) {
 }
 
 // GenerateTimelineHTML creates a timeline-based HTML report
-func (f *TimelineFormatter) GenerateTimelineHTML(configName string, history []storage.ConfigMetadata, securityHistory []storage.StoredSecurityAnalysis) ([]byte, error) {
+func (f *TimelineFormatter) GenerateTimelineHTML(configName string, history []storage.ConfigMetadata, securityHistory []storage.StoredSecurityAnalysis, lifecycle []storage.FindingLifecycle) ([]byte, error) {
 	if len(history) == 0 {
 		return nil, fmt.Errorf("no configuration history available")
 	}
@@ -145,11 +166,22 @@ func (f *TimelineFormatter) GenerateTimelineHTML(configName string, history []st
 		securityMap[sec.ConfigID] = sec
 	}
 
+	// Findings introduced and resolved by each version after the first
+	introduced := make(map[time.Time]int)
+	resolved := make(map[time.Time]int)
+	for _, l := range lifecycle {
+		introduced[l.FirstSeen.UTC()]++
+		if l.ResolvedAt != nil {
+			resolved[l.ResolvedAt.UTC()]++
+		}
+	}
+
 	for i, config := range history {
 		securityCount := 0
+		riskScore := 0
 		if sec, exists := securityMap[config.ID]; exists {
-			securityCount = len(sec.PrivilegedContainers) + len(sec.CapabilityContainers) + 
-			               len(sec.HostNamespaceWorkloads) + len(sec.HostPathVolumes)
+			securityCount = sec.TotalFindings()
+			riskScore = sec.RiskReport().Cluster.Score
 		}
 
 		totalResources := 0
@@ -163,6 +195,7 @@ func (f *TimelineFormatter) GenerateTimelineHTML(configName string, history []st
 			FormattedTime:   config.Timestamp.Format("Jan 2, 15:04"),
 			TotalResources:  totalResources,
 			SecurityIssues:  securityCount,
+			RiskScore:       riskScore,
 			IsLatest:        i == len(history)-1,
 		}
 
@@ -188,18 +221,25 @@ func (f *TimelineFormatter) GenerateTimelineHTML(configName string, history []st
 				}
 			}
 			timeline[i].ResourceChanges = resourceChanges
+			timeline[i].Introduced = introduced[config.Timestamp.UTC()]
+			timeline[i].Resolved = resolved[config.Timestamp.UTC()]
 
 			// Calculate security changes
 			prevSecurityCount := 0
+			prevRiskScore := 0
 			if prevSec, exists := securityMap[prevConfig.ID]; exists {
-				prevSecurityCount = len(prevSec.PrivilegedContainers) + len(prevSec.CapabilityContainers) + 
-				                   len(prevSec.HostNamespaceWorkloads) + len(prevSec.HostPathVolumes)
+				prevSecurityCount = prevSec.TotalFindings()
+				prevRiskScore = prevSec.RiskReport().Cluster.Score
 			}
 
+			if securityCount != prevSecurityCount || riskScore != prevRiskScore {
+				timeline[i].SecurityChanges = make(map[string]int)
+			}
 			if securityCount != prevSecurityCount {
-				timeline[i].SecurityChanges = map[string]int{
-					"total": securityCount - prevSecurityCount,
-				}
+				timeline[i].SecurityChanges["total"] = securityCount - prevSecurityCount
+			}
+			if riskScore != prevRiskScore {
+				timeline[i].SecurityChanges["risk score"] = riskScore - prevRiskScore
 			}
 		}
 	}
@@ -207,6 +247,8 @@ func (f *TimelineFormatter) GenerateTimelineHTML(configName string, history []st
 	// Build resource trends
 	resourceTrends := f.buildResourceTrends(history)
 	securityTrends := f.buildSecurityTrends(history, securityHistory)
+	riskTrends := f.buildRiskTrends(history, securityMap)
+	resolvedFindings, openFindings := f.buildLifecycleRows(lifecycle)
 
 	// Build snapshots
 	latest := history[len(history)-1]
@@ -219,6 +261,11 @@ func (f *TimelineFormatter) GenerateTimelineHTML(configName string, history []st
 		previousSnapshot = &prevSnap
 	}
 
+	var remediation []storage.RemediationStats
+	if len(lifecycle) > 0 {
+		remediation = storage.SummarizeRemediation(lifecycle)
+	}
+
 	data := TimelineData{
 		Title:            "Configuration Timeline Report - " + configName,
 		GeneratedAt:      time.Now().Format(time.RFC1123),
@@ -228,6 +275,10 @@ func (f *TimelineFormatter) GenerateTimelineHTML(configName string, history []st
 		Timeline:         timeline,
 		ResourceTrends:   resourceTrends,
 		SecurityTrends:   securityTrends,
+		RiskTrends:       riskTrends,
+		Remediation:      remediation,
+		ResolvedFindings: resolvedFindings,
+		OpenFindings:     openFindings,
 		CurrentSnapshot:  currentSnapshot,
 		PreviousSnapshot: previousSnapshot,
 	}
@@ -316,15 +367,14 @@ func (f *TimelineFormatter) buildSecurityTrends(history []storage.ConfigMetadata
 		securityMap[sec.ConfigID] = sec
 	}
 
-	findingTypes := []string{
-		"Privileged Containers",
-		"Capability Containers", 
-		"Host Namespace Usage",
-		"Host Path Volumes",
-	}
-
-	var trends []SecurityTrend
-	for _, findingType := range findingTypes {
+	// The cluster's risk score leads, as it weighs every finding by severity
+	trends := []SecurityTrend{newSecurityTrend("Risk Score", history, func(config storage.ConfigMetadata) int {
+		if sec, exists := securityMap[config.ID]; exists {
+			return sec.RiskReport().Cluster.Score
+		}
+		return 0
+	})}
+	for _, analyzer := range kubernetes.Analyzers() {
 		var dataPoints []TrendPoint
 		first := 0
 		last := 0
@@ -332,16 +382,7 @@ func (f *TimelineFormatter) buildSecurityTrends(history []storage.ConfigMetadata
 		for _, config := range history {
 			count := 0
 			if sec, exists := securityMap[config.ID]; exists {
-				switch findingType {
-				case "Privileged Containers":
-					count = len(sec.PrivilegedContainers)
-				case "Capability Containers":
-					count = len(sec.CapabilityContainers)
-				case "Host Namespace Usage":
-					count = len(sec.HostNamespaceWorkloads)
-				case "Host Path Volumes":
-					count = len(sec.HostPathVolumes)
-				}
+				count = sec.FindingCounts()[analyzer.ID()]
 			}
 
 			dataPoints = append(dataPoints, TrendPoint{
@@ -364,7 +405,7 @@ func (f *TimelineFormatter) buildSecurityTrends(history []storage.ConfigMetadata
 		}
 
 		trends = append(trends, SecurityTrend{
-			FindingType: findingType,
+			FindingType: analyzer.Name(),
 			DataPoints:  dataPoints,
 			TotalChange: totalChange,
 			Trend:       trend,
@@ -374,6 +415,138 @@ func (f *TimelineFormatter) buildSecurityTrends(history []storage.ConfigMetadata
 	return trends
 }
 
+// buildRiskTrends creates the risk score trend of each namespace whose score changed
+// between the first and last versions, largest changes first
+func (f *TimelineFormatter) buildRiskTrends(history []storage.ConfigMetadata, securityMap map[string]storage.StoredSecurityAnalysis) []SecurityTrend {
+	if len(history) < 2 {
+		return nil
+	}
+
+	reports := make(map[string]kubernetes.RiskReport)
+	namespaces := make(map[string]bool)
+	for _, config := range history {
+		if sec, exists := securityMap[config.ID]; exists {
+			report := sec.RiskReport()
+			reports[config.ID] = report
+			for _, ns := range report.Namespaces {
+				namespaces[ns.Namespace] = true
+			}
+		}
+	}
+
+	var trends []SecurityTrend
+	for namespace := range namespaces {
+		trend := newSecurityTrend(namespace, history, func(config storage.ConfigMetadata) int {
+			return reports[config.ID].NamespaceScore(namespace).Score
+		})
+		if trend.TotalChange != 0 {
+			trends = append(trends, trend)
+		}
+	}
+
+	sort.Slice(trends, func(i, j int) bool {
+		if abs(trends[i].TotalChange) != abs(trends[j].TotalChange) {
+			return abs(trends[i].TotalChange) > abs(trends[j].TotalChange)
+		}
+		return trends[i].FindingType < trends[j].FindingType
+	})
+	if len(trends) > 10 {
+		trends = trends[:10]
+	}
+	return trends
+}
+
+// newSecurityTrend builds a trend from a value of every version
+func newSecurityTrend(name string, history []storage.ConfigMetadata, value func(config storage.ConfigMetadata) int) SecurityTrend {
+	var dataPoints []TrendPoint
+	for _, config := range history {
+		dataPoints = append(dataPoints, TrendPoint{
+			Timestamp: config.Timestamp,
+			Value:     value(config),
+		})
+	}
+
+	totalChange := dataPoints[len(dataPoints)-1].Value - dataPoints[0].Value
+	trend := "stable"
+	if totalChange > 0 {
+		trend = "increasing"
+	} else if totalChange < 0 {
+		trend = "decreasing"
+	}
+
+	return SecurityTrend{
+		FindingType: name,
+		DataPoints:  dataPoints,
+		TotalChange: totalChange,
+		Trend:       trend,
+	}
+}
+
+// buildLifecycleRows lists the 20 most recently resolved findings and the 20 findings
+// open the longest
+func (f *TimelineFormatter) buildLifecycleRows(lifecycle []storage.FindingLifecycle) (resolved, open []LifecycleRow) {
+	var resolvedFindings, openFindings []storage.FindingLifecycle
+	for _, l := range lifecycle {
+		if l.Open() {
+			openFindings = append(openFindings, l)
+		} else {
+			resolvedFindings = append(resolvedFindings, l)
+		}
+	}
+	sort.SliceStable(resolvedFindings, func(i, j int) bool {
+		return resolvedFindings[i].ResolvedAt.After(*resolvedFindings[j].ResolvedAt)
+	})
+	sort.SliceStable(openFindings, func(i, j int) bool {
+		return openFindings[i].Age() > openFindings[j].Age()
+	})
+	if len(resolvedFindings) > 20 {
+		resolvedFindings = resolvedFindings[:20]
+	}
+	if len(openFindings) > 20 {
+		openFindings = openFindings[:20]
+	}
+
+	row := func(l storage.FindingLifecycle) LifecycleRow {
+		check := l.Finding.AnalyzerID
+		if analyzer, ok := kubernetes.GetAnalyzer(check); ok {
+			check = analyzer.Name()
+		}
+		r := LifecycleRow{
+			Severity:  string(l.Finding.Severity),
+			Check:     check,
+			Subject:   l.Finding.Subject(),
+			Details:   l.Finding.Details,
+			FirstSeen: l.FirstSeen.Format("Jan 2, 2006 15:04"),
+			Age:       FormatDuration(l.Age()),
+		}
+		if l.ResolvedAt != nil {
+			r.ResolvedAt = l.ResolvedAt.Format("Jan 2, 2006 15:04")
+		}
+		return r
+	}
+	for _, l := range resolvedFindings {
+		resolved = append(resolved, row(l))
+	}
+	for _, l := range openFindings {
+		open = append(open, row(l))
+	}
+	return resolved, open
+}
+
+// FormatDuration formats how long a finding was open, e.g. "3d 4h", "5h 12m" or "40m"
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
 // buildSnapshot creates a snapshot from configuration metadata
 func (f *TimelineFormatter) buildSnapshot(config storage.ConfigMetadata, security storage.StoredSecurityAnalysis) SnapshotData {
 	totalResources := 0
@@ -381,10 +554,11 @@ func (f *TimelineFormatter) buildSnapshot(config storage.ConfigMetadata, securit
 		totalResources += count
 	}
 
-	privileged := len(security.PrivilegedContainers)
-	capability := len(security.CapabilityContainers)
-	hostNS := len(security.HostNamespaceWorkloads)
-	hostPath := len(security.HostPathVolumes)
+	counts := security.FindingCounts()
+	privileged := counts[kubernetes.CheckPrivileged]
+	capability := counts[kubernetes.CheckCapabilities]
+	hostNS := counts[kubernetes.CheckHostNamespaces]
+	hostPath := counts[kubernetes.CheckHostPath]
 
 	return SnapshotData{
 		ID:                   config.ID,
@@ -396,7 +570,8 @@ func (f *TimelineFormatter) buildSnapshot(config storage.ConfigMetadata, securit
 		CapabilityContainers: capability,
 		HostNamespaceUsage:   hostNS,
 		HostPathVolumes:      hostPath,
-		TotalSecurityIssues:  privileged + capability + hostNS + hostPath,
+		TotalSecurityIssues:  security.TotalFindings(),
+		Risk:                 security.RiskReport().Cluster,
 	}
 }
 
@@ -307,6 +307,31 @@ const timelineTemplate = `<!DOCTYPE html>
             color: var(--secondary-color);
         }
 
+        .lifecycle-table {
+            width: 100%;
+            border-collapse: collapse;
+            margin-bottom: 25px;
+            font-size: 0.9em;
+        }
+
+        .lifecycle-table th,
+        .lifecycle-table td {
+            padding: 8px 10px;
+            text-align: left;
+            border-bottom: 1px solid #eee;
+        }
+
+        .lifecycle-table th {
+            background: #f8f9fa;
+            color: var(--secondary-color);
+        }
+
+        .lifecycle-title {
+            font-weight: bold;
+            margin-bottom: 10px;
+            color: var(--secondary-color);
+        }
+
         .no-data {
             text-align: center;
             color: #666;
@@ -358,6 +383,10 @@ const timelineTemplate = `<!DOCTYPE html>
                 <span class="stat-number">{{ .CurrentSnapshot.TotalSecurityIssues }}</span>
                 <div class="stat-label">Security Issues</div>
             </div>
+            <div class="stat-card danger">
+                <span class="stat-number">{{ .CurrentSnapshot.Risk.Score }}</span>
+                <div class="stat-label">Risk Score</div>
+            </div>
             <div class="stat-card">
                 <span class="stat-number">{{ .TimeSpan }}</span>
                 <div class="stat-label">Time Span</div>
@@ -375,6 +404,14 @@ const timelineTemplate = `<!DOCTYPE html>
                             <span class="metric-label">Total Resources</span>
                             <span class="metric-value">{{ .CurrentSnapshot.TotalResources }}</span>
                         </div>
+                        <div class="metric-row">
+                            <span class="metric-label">Risk Score</span>
+                            <span class="metric-value">{{ .CurrentSnapshot.Risk.Score }}</span>
+                        </div>
+                        <div class="metric-row">
+                            <span class="metric-label">Critical / High Findings</span>
+                            <span class="metric-value">{{ .CurrentSnapshot.Risk.Critical }} / {{ .CurrentSnapshot.Risk.High }}</span>
+                        </div>
                         <div class="metric-row">
                             <span class="metric-label">Privileged Containers</span>
                             <span class="metric-value">{{ .CurrentSnapshot.PrivilegedContainers }}</span>
@@ -398,6 +435,14 @@ const timelineTemplate = `<!DOCTYPE html>
                             <span class="metric-label">Total Resources</span>
                             <span class="metric-value">{{ .PreviousSnapshot.TotalResources }}</span>
                         </div>
+                        <div class="metric-row">
+                            <span class="metric-label">Risk Score</span>
+                            <span class="metric-value">{{ .PreviousSnapshot.Risk.Score }}</span>
+                        </div>
+                        <div class="metric-row">
+                            <span class="metric-label">Critical / High Findings</span>
+                            <span class="metric-value">{{ .PreviousSnapshot.Risk.Critical }} / {{ .PreviousSnapshot.Risk.High }}</span>
+                        </div>
                         <div class="metric-row">
                             <span class="metric-label">Privileged Containers</span>
                             <span class="metric-value">{{ .PreviousSnapshot.PrivilegedContainers }}</span>
@@ -456,6 +501,93 @@ const timelineTemplate = `<!DOCTYPE html>
         </div>
         {{end}}
 
+        {{if .RiskTrends}}
+        <div class="section">
+            <div class="section-header">Namespace Risk Trends</div>
+            <div class="section-content">
+                <div class="trends">
+                    {{range .RiskTrends}}
+                    <div class="trend-card">
+                        <div class="trend-header">{{ .FindingType }}</div>
+                        <div class="trend-value {{ trendClass .Trend }}">
+                            {{ formatChange .TotalChange }} ({{ .Trend }})
+                        </div>
+                    </div>
+                    {{end}}
+                </div>
+            </div>
+        </div>
+        {{end}}
+
+        {{if .Remediation}}
+        <div class="section">
+            <div class="section-header">Finding Lifecycle</div>
+            <div class="section-content">
+                <div class="lifecycle-title">Time to Remediate</div>
+                <table class="lifecycle-table">
+                    <thead>
+                        <tr><th>Severity</th><th>Open</th><th>Resolved</th><th>Mean</th><th>Median</th><th>Longest</th></tr>
+                    </thead>
+                    <tbody>
+                        {{range .Remediation}}
+                        <tr>
+                            <td>{{if .Severity}}{{ .Severity }}{{else}}all{{end}}</td>
+                            <td>{{ .Open }}</td>
+                            <td>{{ .Resolved }}</td>
+                            <td>{{if .Resolved}}{{ duration .Mean }}{{else}}-{{end}}</td>
+                            <td>{{if .Resolved}}{{ duration .Median }}{{else}}-{{end}}</td>
+                            <td>{{if .Resolved}}{{ duration .Longest }}{{else}}-{{end}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+
+                {{if .ResolvedFindings}}
+                <div class="lifecycle-title">Recently Resolved Findings</div>
+                <table class="lifecycle-table">
+                    <thead>
+                        <tr><th>Severity</th><th>Check</th><th>Resource</th><th>Details</th><th>First Seen</th><th>Resolved</th><th>Time to Resolve</th></tr>
+                    </thead>
+                    <tbody>
+                        {{range .ResolvedFindings}}
+                        <tr>
+                            <td>{{ .Severity }}</td>
+                            <td>{{ .Check }}</td>
+                            <td>{{ .Subject }}</td>
+                            <td>{{ .Details }}</td>
+                            <td>{{ .FirstSeen }}</td>
+                            <td>{{ .ResolvedAt }}</td>
+                            <td>{{ .Age }}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+                {{end}}
+
+                {{if .OpenFindings}}
+                <div class="lifecycle-title">Longest Open Findings</div>
+                <table class="lifecycle-table">
+                    <thead>
+                        <tr><th>Severity</th><th>Check</th><th>Resource</th><th>Details</th><th>First Seen</th><th>Open For</th></tr>
+                    </thead>
+                    <tbody>
+                        {{range .OpenFindings}}
+                        <tr>
+                            <td>{{ .Severity }}</td>
+                            <td>{{ .Check }}</td>
+                            <td>{{ .Subject }}</td>
+                            <td>{{ .Details }}</td>
+                            <td>{{ .FirstSeen }}</td>
+                            <td>{{ .Age }}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+                {{end}}
+            </div>
+        </div>
+        {{end}}
+
         <div class="section">
             <div class="section-header">Configuration Timeline</div>
             <div class="section-content">
@@ -476,6 +608,10 @@ const timelineTemplate = `<!DOCTYPE html>
                                 <div class="timeline-stat-value">{{ .SecurityIssues }}</div>
                                 <div class="timeline-stat-label">Security Issues</div>
                             </div>
+                            <div class="timeline-stat">
+                                <div class="timeline-stat-value">{{ .RiskScore }}</div>
+                                <div class="timeline-stat-label">Risk Score</div>
+                            </div>
                         </div>
 
                         {{if .ResourceChanges}}
@@ -499,6 +635,14 @@ const timelineTemplate = `<!DOCTYPE html>
                             {{end}}
                         </div>
                         {{end}}
+
+                        {{if or .Introduced .Resolved}}
+                        <div class="changes">
+                            <strong>Findings:</strong>
+                            {{if .Introduced}}<span class="change-item negative">{{ .Introduced }} introduced</span>{{end}}
+                            {{if .Resolved}}<span class="change-item positive">{{ .Resolved }} resolved</span>{{end}}
+                        </div>
+                        {{end}}
                     </div>
                     {{end}}
                 </div>
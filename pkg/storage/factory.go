@@ -3,6 +3,8 @@ package storage
 import (
 	"fmt"
 	"path/filepath"
+
+	"github.com/raesene/eolas/pkg/kubernetes"
 )
 
 // Backend represents the available storage backend types
@@ -17,20 +19,31 @@ const (
 
 // StorageConfig contains configuration for creating a storage backend
 type StorageConfig struct {
-	Backend    Backend
-	StorageDir string
-	UseHomeDir bool
+	Backend         Backend
+	StorageDir      string
+	UseHomeDir      bool
+	AnalyzerOptions kubernetes.Options // options of the analyzers run when saving or comparing
 }
 
 // NewStore creates a new storage backend based on the provided configuration
 func NewStore(config StorageConfig) (Store, error) {
 	switch config.Backend {
 	case FileBackend:
-		return NewFileStore(config.StorageDir)
+		store, err := NewFileStore(config.StorageDir)
+		if err != nil {
+			return nil, err
+		}
+		store.Options = config.AnalyzerOptions
+		return store, nil
 	case SQLiteBackend:
 		// For SQLite, use the storage directory to determine database location
 		dbPath := filepath.Join(config.StorageDir, "eolas.db")
-		return NewSQLiteStore(dbPath)
+		store, err := NewSQLiteStore(dbPath)
+		if err != nil {
+			return nil, err
+		}
+		store.options = config.AnalyzerOptions
+		return store, nil
 	default:
 		return nil, fmt.Errorf("unsupported storage backend: %s", config.Backend)
 	}
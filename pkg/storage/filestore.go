@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/raesene/eolas/pkg/kubernetes"
@@ -13,8 +14,13 @@ import (
 // FileStore handles saving and loading Kubernetes configurations
 type FileStore struct {
 	StorageDir string
+	Options    kubernetes.Options // analyzer options used when comparing configurations
 }
 
+// metadataSuffix is appended to a configuration's name for the file holding its
+// metadata, written at save time so listing configurations does not parse them
+const metadataSuffix = ".meta.json"
+
 // NewFileStore creates a new file storage handler
 func NewFileStore(storageDir string) (*FileStore, error) {
 	// Create storage directory if it doesn't exist
@@ -29,43 +35,26 @@ func NewFileStore(storageDir string) (*FileStore, error) {
 
 // SaveConfig saves a Kubernetes configuration to the file store
 func (fs *FileStore) SaveConfig(config *kubernetes.ClusterConfig, name string) error {
-	if name == "" {
-		name = fmt.Sprintf("cluster_%s", time.Now().Format("20060102_150405"))
-	}
-	
-	filePath := filepath.Join(fs.StorageDir, fmt.Sprintf("%s.json", name))
-	
-	// Convert config to JSON
-	data, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
-	}
-	
-	// Write to file
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
-	}
-	
-	return nil
+	return fs.SaveConfigWithMetadata(config, ConfigMetadata{Name: name})
 }
 
 // LoadConfig loads a Kubernetes configuration from the file store
 func (fs *FileStore) LoadConfig(name string) (*kubernetes.ClusterConfig, error) {
 	filePath := filepath.Join(fs.StorageDir, fmt.Sprintf("%s.json", name))
 	
-	// Read file
-	data, err := os.ReadFile(filePath)
+	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	defer file.Close()
 	
-	// Parse JSON
-	var config kubernetes.ClusterConfig
-	if err := json.Unmarshal(data, &config); err != nil {
+	// Decode items one at a time
+	config, err := kubernetes.ParseConfigStream(file)
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 	
-	return &config, nil
+	return config, nil
 }
 
 // ListConfigs returns a list of saved configurations
@@ -78,6 +67,9 @@ func (fs *FileStore) ListConfigs() ([]string, error) {
 	}
 	
 	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), metadataSuffix) {
+			continue
+		}
 		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
 			configs = append(configs, filepath.Base(entry.Name()[:len(entry.Name())-5]))
 		}
@@ -89,14 +81,133 @@ func (fs *FileStore) ListConfigs() ([]string, error) {
 // SaveConfigWithMetadata saves a configuration with full metadata (file-based implementation)
 // Note: File storage has limited metadata support compared to SQLite
 func (fs *FileStore) SaveConfigWithMetadata(config *kubernetes.ClusterConfig, metadata ConfigMetadata) error {
+	writer, err := fs.BeginConfig(metadata)
+	if err != nil {
+		return err
+	}
+	
+	for _, item := range config.Items {
+		if err := writer.Add(item); err != nil {
+			writer.Abort()
+			return err
+		}
+	}
+	
+	return writer.Commit(config)
+}
+
+// BeginConfig starts writing a configuration to a temporary file, which replaces
+// <name>.json on Commit so a failed ingest leaves any earlier configuration in place
+func (fs *FileStore) BeginConfig(metadata ConfigMetadata) (ConfigWriter, error) {
 	// Use the name from metadata, or generate one if empty
-	name := metadata.Name
-	if name == "" {
-		name = fmt.Sprintf("cluster_%s", time.Now().Format("20060102_150405"))
+	if metadata.Name == "" {
+		metadata.Name = fmt.Sprintf("cluster_%s", time.Now().Format("20060102_150405"))
 	}
 	
-	// File storage uses name as filename, so we'll save the basic config
-	return fs.SaveConfig(config, name)
+	file, err := os.CreateTemp(fs.StorageDir, "."+metadata.Name+"-*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+	
+	// Items are written one at a time rather than marshalling the whole config
+	return &fileConfigWriter{
+		store:    fs,
+		file:     file,
+		encoder:  kubernetes.NewItemEncoder(file, "v1", "List"),
+		metadata: metadata,
+	}, nil
+}
+
+// fileConfigWriter writes a configuration as a JSON List item by item
+type fileConfigWriter struct {
+	store    *FileStore
+	file     *os.File
+	encoder  *kubernetes.ItemEncoder
+	metadata ConfigMetadata
+}
+
+// Add writes one item
+func (w *fileConfigWriter) Add(item kubernetes.Item) error {
+	if err := w.encoder.Encode(item); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// Commit moves the file into place as <name>.json and writes <name>.meta.json,
+// computing the resource counts and fingerprint from config
+func (w *fileConfigWriter) Commit(config *kubernetes.ClusterConfig) error {
+	if err := w.encoder.Close(); err != nil {
+		w.Abort()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := w.file.Close(); err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	
+	// File storage uses name as filename
+	filePath := filepath.Join(w.store.StorageDir, fmt.Sprintf("%s.json", w.metadata.Name))
+	if err := os.Chmod(w.file.Name(), 0644); err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Rename(w.file.Name(), filePath); err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	
+	// Complete the metadata the way the SQLite store does
+	metadata := w.metadata
+	metadata.ID = metadata.Name
+	if metadata.Timestamp.IsZero() {
+		metadata.Timestamp = time.Now()
+	}
+	if metadata.CreatedAt.IsZero() {
+		metadata.CreatedAt = time.Now()
+	}
+	if metadata.ResourceCounts == nil {
+		metadata.ResourceCounts = kubernetes.GetResourceCounts(config)
+	}
+	if metadata.Fingerprint == nil {
+		fingerprint := kubernetes.FingerprintCluster(config)
+		metadata.Fingerprint = &fingerprint
+	}
+	
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	metadataPath := filepath.Join(w.store.StorageDir, metadata.Name+metadataSuffix)
+	if err := os.WriteFile(metadataPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write metadata file: %w", err)
+	}
+	
+	return nil
+}
+
+// Abort removes the temporary file
+func (w *fileConfigWriter) Abort() error {
+	w.file.Close()
+	return os.Remove(w.file.Name())
+}
+
+// readMetadata reads the metadata saved next to a configuration. It returns nil
+// without an error for configurations saved before metadata was written.
+func (fs *FileStore) readMetadata(name string) (*ConfigMetadata, error) {
+	data, err := os.ReadFile(filepath.Join(fs.StorageDir, name+metadataSuffix))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read metadata file: %w", err)
+	}
+	
+	var metadata ConfigMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	return &metadata, nil
 }
 
 // LoadConfigByID loads a configuration by ID (file storage treats ID as filename)
@@ -108,16 +219,25 @@ func (fs *FileStore) LoadConfigByID(id string) (*kubernetes.ClusterConfig, error
 // GetConfigHistory returns configuration history (limited for file storage)
 func (fs *FileStore) GetConfigHistory(name string) ([]ConfigMetadata, error) {
 	// File storage doesn't maintain history, so return single entry if exists
-	config, err := fs.LoadConfig(name)
+	filePath := filepath.Join(fs.StorageDir, fmt.Sprintf("%s.json", name))
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	
+	// Metadata written at save time avoids parsing the configuration
+	stored, err := fs.readMetadata(name)
 	if err != nil {
 		return nil, err
 	}
+	if stored != nil {
+		return []ConfigMetadata{*stored}, nil
+	}
 	
-	// Get file info for timestamp
-	filePath := filepath.Join(fs.StorageDir, fmt.Sprintf("%s.json", name))
-	fileInfo, err := os.Stat(filePath)
+	// Configurations saved before metadata was written are parsed instead
+	config, err := fs.LoadConfig(name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get file info: %w", err)
+		return nil, err
 	}
 	
 	metadata := ConfigMetadata{
@@ -128,6 +248,10 @@ func (fs *FileStore) GetConfigHistory(name string) ([]ConfigMetadata, error) {
 		ResourceCounts: kubernetes.GetResourceCounts(config),
 	}
 	
+	// No metadata is stored alongside the file, so the fingerprint is detected on load
+	fingerprint := kubernetes.FingerprintCluster(config)
+	metadata.Fingerprint = &fingerprint
+	
 	return []ConfigMetadata{metadata}, nil
 }
 
@@ -156,6 +280,11 @@ func (fs *FileStore) DeleteConfig(id string) error {
 		return fmt.Errorf("failed to delete configuration: %w", err)
 	}
 	
+	metadataPath := filepath.Join(fs.StorageDir, id+metadataSuffix)
+	if err := os.Remove(metadataPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete configuration metadata: %w", err)
+	}
+	
 	return nil
 }
 
@@ -209,42 +338,24 @@ func (fs *FileStore) CompareConfigs(id1, id2 string) (*ConfigComparison, error)
 		}
 	}
 	
-	// Basic security comparison (real-time analysis)
-	privileged1 := kubernetes.GetPrivilegedContainers(config1)
-	privileged2 := kubernetes.GetPrivilegedContainers(config2)
-	
-	capability1 := kubernetes.GetCapabilityContainers(config1)
-	capability2 := kubernetes.GetCapabilityContainers(config2)
-	
-	hostNamespace1 := kubernetes.GetHostNamespaceWorkloads(config1)
-	hostNamespace2 := kubernetes.GetHostNamespaceWorkloads(config2)
-	
-	hostPath1 := kubernetes.GetHostPathVolumes(config1)
-	hostPath2 := kubernetes.GetHostPathVolumes(config2)
-	
-	securityDiff := SecurityDifference{
-		PrivilegedContainers: SecurityFindingDiff{
-			Before: len(privileged1),
-			After:  len(privileged2),
-			Change: len(privileged2) - len(privileged1),
-		},
-		CapabilityContainers: SecurityFindingDiff{
-			Before: len(capability1),
-			After:  len(capability2),
-			Change: len(capability2) - len(capability1),
-		},
-		HostNamespaceUsage: SecurityFindingDiff{
-			Before: len(hostNamespace1),
-			After:  len(hostNamespace2),
-			Change: len(hostNamespace2) - len(hostNamespace1),
-		},
-		HostPathVolumes: SecurityFindingDiff{
-			Before: len(hostPath1),
-			After:  len(hostPath2),
-			Change: len(hostPath2) - len(hostPath1),
-		},
+	// Basic security comparison (real-time analysis of every registered analyzer)
+	opts1, opts2 := fs.Options, fs.Options
+	opts1.Now, opts2.Now = metadata1.Timestamp, metadata2.Timestamp
+	findings1 := kubernetes.RunAnalyzers(config1, opts1)
+	before := make(map[string]int)
+	for id, findings := range findings1 {
+		before[id] = len(findings)
 	}
 	
+	findings2 := kubernetes.RunAnalyzers(config2, opts2)
+	after := make(map[string]int)
+	for id, findings := range findings2 {
+		after[id] = len(findings)
+	}
+	
+	securityDiff := newSecurityDifference(before, after)
+	securityDiff.addFindingChanges(findings1, findings2)
+	
 	return &ConfigComparison{
 		Config1:      *metadata1,
 		Config2:      *metadata2,
@@ -260,6 +371,12 @@ func (fs *FileStore) GetSecurityAnalysisHistory(name string) ([]StoredSecurityAn
 	return []StoredSecurityAnalysis{}, nil
 }
 
+// GetFindingLifecycle returns finding lifecycles (not tracked by file storage)
+func (fs *FileStore) GetFindingLifecycle(name string) ([]FindingLifecycle, error) {
+	// File storage keeps a single version per name, so findings have no history
+	return []FindingLifecycle{}, nil
+}
+
 // Close is a no-op for file storage
 func (fs *FileStore) Close() error {
 	return nil
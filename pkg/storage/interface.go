@@ -13,6 +13,7 @@ type Store interface {
 	
 	// Enhanced operations for comparison and history
 	SaveConfigWithMetadata(config *kubernetes.ClusterConfig, metadata ConfigMetadata) error
+	BeginConfig(metadata ConfigMetadata) (ConfigWriter, error)
 	LoadConfigByID(id string) (*kubernetes.ClusterConfig, error)
 	GetConfigHistory(name string) ([]ConfigMetadata, error)
 	GetConfigMetadata(id string) (*ConfigMetadata, error)
@@ -23,7 +24,19 @@ type Store interface {
 	
 	// Security analysis operations
 	GetSecurityAnalysisHistory(name string) ([]StoredSecurityAnalysis, error)
+	GetFindingLifecycle(name string) ([]FindingLifecycle, error)
 	
 	// Storage management
 	Close() error
-}
\ No newline at end of file
+}
+// ConfigWriter saves a configuration item by item, so ingest can store items as they
+// are decoded instead of collecting the whole configuration first
+type ConfigWriter interface {
+	// Add stores one item of the configuration
+	Add(item kubernetes.Item) error
+	// Commit finishes the save. The metadata and stored analysis are computed from
+	// config, which may hold compact copies of the added items (see Item.Compact).
+	Commit(config *kubernetes.ClusterConfig) error
+	// Abort discards the items added so far
+	Abort() error
+}
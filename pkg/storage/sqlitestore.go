@@ -15,6 +15,7 @@ import (
 type SQLiteStore struct {
 	db       *sql.DB
 	dbPath   string
+	options  kubernetes.Options // analyzer options used for the stored security analysis
 }
 
 // NewSQLiteStore creates a new SQLite storage handler
@@ -40,6 +41,23 @@ func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
 
 // initSchema creates the required database tables
 func (s *SQLiteStore) initSchema() error {
+	// Databases created before finding lifecycles were tracked are backfilled from their
+	// history, and lifecycles recorded before findings had rules, when fingerprints
+	// hashed the details, are rebuilt
+	var lifecycleTables, ruleColumns int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'finding_lifecycle'
+	`).Scan(&lifecycleTables)
+	if err != nil {
+		return fmt.Errorf("failed to inspect schema: %w", err)
+	}
+	err = s.db.QueryRow(`
+		SELECT COUNT(*) FROM pragma_table_info('finding_lifecycle') WHERE name = 'rule'
+	`).Scan(&ruleColumns)
+	if err != nil {
+		return fmt.Errorf("failed to inspect schema: %w", err)
+	}
+	
 	schema := `
 	CREATE TABLE IF NOT EXISTS configs (
 		id TEXT PRIMARY KEY,
@@ -49,24 +67,108 @@ func (s *SQLiteStore) initSchema() error {
 		resource_counts TEXT,
 		tags TEXT,
 		description TEXT,
-		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		fingerprint TEXT
 	);
 	
 	CREATE INDEX IF NOT EXISTS idx_name_timestamp ON configs(name, timestamp);
 	CREATE INDEX IF NOT EXISTS idx_created_at ON configs(created_at);
 	
+	CREATE TABLE IF NOT EXISTS config_items (
+		config_id TEXT NOT NULL,
+		seq INTEGER NOT NULL,
+		data TEXT NOT NULL,
+		PRIMARY KEY (config_id, seq),
+		FOREIGN KEY (config_id) REFERENCES configs(id) ON DELETE CASCADE
+	);
+	
 	CREATE TABLE IF NOT EXISTS security_analysis (
 		config_id TEXT PRIMARY KEY,
 		privileged_containers TEXT,
 		capability_containers TEXT,
 		host_namespace_workloads TEXT,
 		host_path_volumes TEXT,
+		findings TEXT,
+		risk TEXT,
 		FOREIGN KEY (config_id) REFERENCES configs(id) ON DELETE CASCADE
 	);
+	
+	CREATE TABLE IF NOT EXISTS finding_lifecycle (
+		config_name TEXT NOT NULL,
+		fingerprint TEXT NOT NULL,
+		analyzer_id TEXT NOT NULL,
+		severity TEXT,
+		namespace TEXT,
+		kind TEXT,
+		name TEXT,
+		container TEXT,
+		rule TEXT,
+		details TEXT,
+		first_seen DATETIME NOT NULL,
+		last_seen DATETIME NOT NULL,
+		resolved_at DATETIME,
+		PRIMARY KEY (config_name, fingerprint)
+	);
 	`
 	
-	_, err := s.db.Exec(schema)
-	return err
+	if _, err := s.db.Exec(schema); err != nil {
+		return err
+	}
+	
+	// Databases created before analyzers were pluggable lack the findings column
+	if err := s.addColumnIfMissing("security_analysis", "findings", "TEXT"); err != nil {
+		return err
+	}
+	
+	// Databases created before risk scoring lack the risk column
+	if err := s.addColumnIfMissing("security_analysis", "risk", "TEXT"); err != nil {
+		return err
+	}
+	
+	// Databases created before cluster fingerprinting lack the fingerprint column
+	if err := s.addColumnIfMissing("configs", "fingerprint", "TEXT"); err != nil {
+		return err
+	}
+	
+	if err := s.addColumnIfMissing("finding_lifecycle", "rule", "TEXT"); err != nil {
+		return err
+	}
+	
+	if lifecycleTables == 0 || ruleColumns == 0 {
+		return s.backfillFindingLifecycle()
+	}
+	return nil
+}
+
+// addColumnIfMissing adds a column to an existing table created by an older schema
+func (s *SQLiteStore) addColumnIfMissing(table, column, columnType string) error {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	defer rows.Close()
+	
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, ctype string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &ctype, &notNull, &defaultValue, &pk); err != nil {
+			return fmt.Errorf("failed to inspect table %s: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	
+	_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, columnType))
+	if err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+	return nil
 }
 
 // SaveConfig saves a configuration with a generated name (legacy interface)
@@ -84,11 +186,28 @@ func (s *SQLiteStore) SaveConfig(config *kubernetes.ClusterConfig, name string)
 
 // SaveConfigWithMetadata saves a configuration with full metadata
 func (s *SQLiteStore) SaveConfigWithMetadata(config *kubernetes.ClusterConfig, metadata ConfigMetadata) error {
+	writer, err := s.BeginConfig(metadata)
+	if err != nil {
+		return err
+	}
+	
+	for _, item := range config.Items {
+		if err := writer.Add(item); err != nil {
+			writer.Abort()
+			return err
+		}
+	}
+	
+	return writer.Commit(config)
+}
+
+// BeginConfig starts saving a configuration in a transaction. Items are inserted into
+// config_items as they are added and the configuration only becomes visible on Commit.
+func (s *SQLiteStore) BeginConfig(metadata ConfigMetadata) (ConfigWriter, error) {
 	tx, err := s.db.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer tx.Rollback()
 	
 	// Generate ID if not provided
 	if metadata.ID == "" {
@@ -103,15 +222,73 @@ func (s *SQLiteStore) SaveConfigWithMetadata(config *kubernetes.ClusterConfig, m
 		metadata.CreatedAt = time.Now()
 	}
 	
+	tagsJSON, err := json.Marshal(metadata.Tags)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to marshal tags: %w", err)
+	}
+	
+	// Insert config record. Items are stored one row each in config_items,
+	// so raw_data is only populated for configurations saved by older versions.
+	// Resource counts and the fingerprint are filled in on Commit.
+	_, err = tx.Exec(`
+		INSERT INTO configs (id, name, timestamp, raw_data, tags, description, created_at)
+		VALUES (?, ?, ?, '', ?, ?, ?)
+	`, metadata.ID, metadata.Name, metadata.Timestamp, 
+		string(tagsJSON), metadata.Description, metadata.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to insert config: %w", err)
+	}
+	
+	stmt, err := tx.Prepare(`INSERT INTO config_items (config_id, seq, data) VALUES (?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to save config items: %w", err)
+	}
+	
+	return &sqliteConfigWriter{store: s, tx: tx, items: stmt, metadata: metadata}, nil
+}
+
+// sqliteConfigWriter saves a configuration item by item in one transaction
+type sqliteConfigWriter struct {
+	store    *SQLiteStore
+	tx       *sql.Tx
+	items    *sql.Stmt
+	metadata ConfigMetadata
+	seq      int
+}
+
+// Add stores one item as its own row
+func (w *sqliteConfigWriter) Add(item kubernetes.Item) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to marshal item %d: %w", w.seq, err)
+	}
+	if _, err := w.items.Exec(w.metadata.ID, w.seq, string(data)); err != nil {
+		return fmt.Errorf("failed to save config item %d: %w", w.seq, err)
+	}
+	w.seq++
+	return nil
+}
+
+// Commit records the resource counts and fingerprint, stores the security analysis
+// of config and commits the transaction
+func (w *sqliteConfigWriter) Commit(config *kubernetes.ClusterConfig) error {
+	defer w.tx.Rollback()
+	w.items.Close()
+	
+	metadata := w.metadata
+	
 	// Set resource counts if not provided
 	if metadata.ResourceCounts == nil {
 		metadata.ResourceCounts = kubernetes.GetResourceCounts(config)
 	}
 	
-	// Serialize config data
-	rawData, err := json.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	// Fingerprint the cluster if not provided
+	if metadata.Fingerprint == nil {
+		fingerprint := kubernetes.FingerprintCluster(config)
+		metadata.Fingerprint = &fingerprint
 	}
 	
 	// Serialize metadata fields
@@ -120,65 +297,334 @@ func (s *SQLiteStore) SaveConfigWithMetadata(config *kubernetes.ClusterConfig, m
 		return fmt.Errorf("failed to marshal resource counts: %w", err)
 	}
 	
-	tagsJSON, err := json.Marshal(metadata.Tags)
+	fingerprintJSON, err := json.Marshal(metadata.Fingerprint)
 	if err != nil {
-		return fmt.Errorf("failed to marshal tags: %w", err)
+		return fmt.Errorf("failed to marshal fingerprint: %w", err)
 	}
 	
-	// Insert config record
-	_, err = tx.Exec(`
-		INSERT INTO configs (id, name, timestamp, raw_data, resource_counts, tags, description, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-	`, metadata.ID, metadata.Name, metadata.Timestamp, string(rawData), 
-		string(resourceCountsJSON), string(tagsJSON), metadata.Description, metadata.CreatedAt)
-	
+	_, err = w.tx.Exec(`
+		UPDATE configs SET resource_counts = ?, fingerprint = ? WHERE id = ?
+	`, string(resourceCountsJSON), string(fingerprintJSON), metadata.ID)
 	if err != nil {
-		return fmt.Errorf("failed to insert config: %w", err)
+		return fmt.Errorf("failed to update config: %w", err)
 	}
 	
 	// Pre-compute and store security analysis
-	if err := s.saveSecurityAnalysis(tx, metadata.ID, config); err != nil {
+	analysis, err := w.store.saveSecurityAnalysis(w.tx, metadata, config)
+	if err != nil {
 		return fmt.Errorf("failed to save security analysis: %w", err)
 	}
 	
-	return tx.Commit()
+	if err := w.store.trackFindings(w.tx, metadata, analysis.Findings); err != nil {
+		return fmt.Errorf("failed to track finding lifecycle: %w", err)
+	}
+	
+	return w.tx.Commit()
+}
+
+// Abort rolls the transaction back, discarding the items added so far
+func (w *sqliteConfigWriter) Abort() error {
+	w.items.Close()
+	return w.tx.Rollback()
 }
 
-// saveSecurityAnalysis pre-computes and stores security analysis results
-func (s *SQLiteStore) saveSecurityAnalysis(tx *sql.Tx, configID string, config *kubernetes.ClusterConfig) error {
+// loadConfigData rebuilds a configuration from its item rows, falling back to the
+// legacy raw_data column for configurations saved before items were stored separately
+func (s *SQLiteStore) loadConfigData(configID, rawData string) (*kubernetes.ClusterConfig, error) {
+	if rawData != "" {
+		var config kubernetes.ClusterConfig
+		if err := json.Unmarshal([]byte(rawData), &config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+		return &config, nil
+	}
+	
+	rows, err := s.db.Query(`
+		SELECT data FROM config_items WHERE config_id = ? ORDER BY seq
+	`, configID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query config items: %w", err)
+	}
+	defer rows.Close()
+	
+	config := &kubernetes.ClusterConfig{
+		ApiVersion: "v1",
+		Kind:       "List",
+	}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan config item: %w", err)
+		}
+		
+		var item kubernetes.Item
+		if err := json.Unmarshal([]byte(data), &item); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config item: %w", err)
+		}
+		config.Items = append(config.Items, item)
+	}
+	
+	return config, rows.Err()
+}
+
+// saveSecurityAnalysis pre-computes and stores security analysis results, measuring
+// time-based checks against the configuration's timestamp
+func (s *SQLiteStore) saveSecurityAnalysis(tx *sql.Tx, metadata ConfigMetadata, config *kubernetes.ClusterConfig) (*StoredSecurityAnalysis, error) {
+	opts := s.options
+	opts.Now = metadata.Timestamp
 	analysis := StoredSecurityAnalysis{
-		ConfigID:               configID,
+		ConfigID:               metadata.ID,
 		PrivilegedContainers:   kubernetes.GetPrivilegedContainers(config),
 		CapabilityContainers:   kubernetes.GetCapabilityContainers(config),
 		HostNamespaceWorkloads: kubernetes.GetHostNamespaceWorkloads(config),
-		HostPathVolumes:        kubernetes.GetHostPathVolumes(config),
+		HostPathVolumes:        kubernetes.GetHostPathVolumes(config, opts),
+		Findings:               kubernetes.RunAnalyzers(config, opts),
 	}
+	risk := kubernetes.ScoreFindings(analysis.Findings)
+	analysis.Risk = &risk
 	
 	// Serialize analysis results
 	privilegedJSON, _ := json.Marshal(analysis.PrivilegedContainers)
 	capabilityJSON, _ := json.Marshal(analysis.CapabilityContainers)
 	hostNamespaceJSON, _ := json.Marshal(analysis.HostNamespaceWorkloads)
 	hostPathJSON, _ := json.Marshal(analysis.HostPathVolumes)
+	findingsJSON, _ := json.Marshal(analysis.Findings)
+	riskJSON, _ := json.Marshal(analysis.Risk)
 	
 	_, err := tx.Exec(`
 		INSERT INTO security_analysis (config_id, privileged_containers, capability_containers, 
-			host_namespace_workloads, host_path_volumes)
-		VALUES (?, ?, ?, ?, ?)
-	`, configID, string(privilegedJSON), string(capabilityJSON), 
-		string(hostNamespaceJSON), string(hostPathJSON))
+			host_namespace_workloads, host_path_volumes, findings, risk)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, metadata.ID, string(privilegedJSON), string(capabilityJSON), 
+		string(hostNamespaceJSON), string(hostPathJSON), string(findingsJSON), string(riskJSON))
+	if err != nil {
+		return nil, err
+	}
+	
+	return &analysis, nil
+}
+
+// trackFindings records the findings of a new version in the finding lifecycle table.
+// A version older than one already stored rewrites the history after it, so the
+// lifecycle of its name is replayed instead.
+func (s *SQLiteStore) trackFindings(tx *sql.Tx, metadata ConfigMetadata, findings map[string][]kubernetes.Finding) error {
+	rows, err := tx.Query(`SELECT timestamp FROM configs WHERE name = ? AND id != ?`, metadata.Name, metadata.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query config history: %w", err)
+	}
+	defer rows.Close()
+	
+	newer := false
+	for rows.Next() {
+		var timestamp time.Time
+		if err := rows.Scan(&timestamp); err != nil {
+			return fmt.Errorf("failed to scan config timestamp: %w", err)
+		}
+		if timestamp.After(metadata.Timestamp) {
+			newer = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	
+	if newer {
+		return s.rebuildFindingLifecycle(tx, metadata.Name)
+	}
+	return s.applyFindings(tx, metadata.Name, metadata.Timestamp, findings)
+}
+
+// applyFindings updates the lifecycle of a configuration's findings with a version
+// taken at timestamp: its findings are opened or seen again, and open findings it
+// no longer has are resolved. A finding that comes back is reopened with its
+// original first-seen time, and an open finding keeps the latest details.
+func (s *SQLiteStore) applyFindings(tx *sql.Tx, name string, timestamp time.Time, findings map[string][]kubernetes.Finding) error {
+	stmt, err := tx.Prepare(`
+		INSERT INTO finding_lifecycle (config_name, fingerprint, analyzer_id, severity, namespace,
+			kind, name, container, rule, details, first_seen, last_seen)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (config_name, fingerprint) DO UPDATE SET
+			severity = excluded.severity, details = excluded.details,
+			last_seen = excluded.last_seen, resolved_at = NULL
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	
+	seen := make(map[string]bool)
+	for _, list := range findings {
+		for _, f := range list {
+			fingerprint := f.Fingerprint()
+			if seen[fingerprint] {
+				continue
+			}
+			seen[fingerprint] = true
+			
+			_, err := stmt.Exec(name, fingerprint, f.AnalyzerID, string(f.Severity), f.Namespace,
+				f.Kind, f.Name, f.Container, f.Rule, f.Details, timestamp, timestamp)
+			if err != nil {
+				return fmt.Errorf("failed to record finding %s: %w", fingerprint, err)
+			}
+		}
+	}
+	
+	rows, err := tx.Query(`
+		SELECT fingerprint FROM finding_lifecycle WHERE config_name = ? AND resolved_at IS NULL
+	`, name)
+	if err != nil {
+		return fmt.Errorf("failed to query open findings: %w", err)
+	}
+	defer rows.Close()
+	
+	var resolved []string
+	for rows.Next() {
+		var fingerprint string
+		if err := rows.Scan(&fingerprint); err != nil {
+			return fmt.Errorf("failed to scan open finding: %w", err)
+		}
+		if !seen[fingerprint] {
+			resolved = append(resolved, fingerprint)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	
+	for _, fingerprint := range resolved {
+		_, err := tx.Exec(`
+			UPDATE finding_lifecycle SET resolved_at = ? WHERE config_name = ? AND fingerprint = ?
+		`, timestamp, name, fingerprint)
+		if err != nil {
+			return fmt.Errorf("failed to resolve finding %s: %w", fingerprint, err)
+		}
+	}
+	
+	return nil
+}
+
+// rebuildFindingLifecycle replays the stored versions of a configuration, oldest
+// first, into the finding lifecycle table. Versions stored before findings were
+// recorded are skipped.
+func (s *SQLiteStore) rebuildFindingLifecycle(tx *sql.Tx, name string) error {
+	if _, err := tx.Exec(`DELETE FROM finding_lifecycle WHERE config_name = ?`, name); err != nil {
+		return fmt.Errorf("failed to clear finding lifecycle: %w", err)
+	}
 	
-	return err
+	rows, err := tx.Query(`
+		SELECT c.timestamp, sa.findings
+		FROM configs c
+		JOIN security_analysis sa ON sa.config_id = c.id
+		WHERE c.name = ?
+		ORDER BY c.timestamp ASC
+	`, name)
+	if err != nil {
+		return fmt.Errorf("failed to query security analysis history: %w", err)
+	}
+	defer rows.Close()
+	
+	type version struct {
+		timestamp time.Time
+		findings  map[string][]kubernetes.Finding
+	}
+	var versions []version
+	for rows.Next() {
+		var v version
+		var findingsJSON sql.NullString
+		if err := rows.Scan(&v.timestamp, &findingsJSON); err != nil {
+			return fmt.Errorf("failed to scan security analysis: %w", err)
+		}
+		if !findingsJSON.Valid || findingsJSON.String == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(findingsJSON.String), &v.findings); err != nil {
+			return fmt.Errorf("failed to unmarshal findings: %w", err)
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	
+	for _, v := range versions {
+		if err := s.applyFindings(tx, name, v.timestamp, v.findings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillFindingLifecycle builds the finding lifecycle of every stored configuration
+func (s *SQLiteStore) backfillFindingLifecycle() error {
+	names, err := s.ListConfigs()
+	if err != nil {
+		return err
+	}
+	
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	
+	for _, name := range names {
+		if err := s.rebuildFindingLifecycle(tx, name); err != nil {
+			return fmt.Errorf("failed to backfill finding lifecycle of %s: %w", name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetFindingLifecycle returns the lifecycle of every finding seen in the versions of
+// a configuration, in the order they were first seen
+func (s *SQLiteStore) GetFindingLifecycle(name string) ([]FindingLifecycle, error) {
+	rows, err := s.db.Query(`
+		SELECT fingerprint, analyzer_id, severity, namespace, kind, name, container,
+			COALESCE(rule, ''), details, first_seen, last_seen, resolved_at
+		FROM finding_lifecycle
+		WHERE config_name = ?
+		ORDER BY first_seen ASC, analyzer_id, namespace, kind, name, container
+	`, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query finding lifecycle: %w", err)
+	}
+	defer rows.Close()
+	
+	var lifecycles []FindingLifecycle
+	for rows.Next() {
+		var l FindingLifecycle
+		var severity string
+		var resolvedAt sql.NullTime
+		
+		err := rows.Scan(&l.Fingerprint, &l.Finding.AnalyzerID, &severity, &l.Finding.Namespace,
+			&l.Finding.Kind, &l.Finding.Name, &l.Finding.Container, &l.Finding.Rule, &l.Finding.Details,
+			&l.FirstSeen, &l.LastSeen, &resolvedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan finding lifecycle: %w", err)
+		}
+		
+		l.Finding.Severity = kubernetes.Severity(severity)
+		if resolvedAt.Valid {
+			l.ResolvedAt = &resolvedAt.Time
+		}
+		lifecycles = append(lifecycles, l)
+	}
+	
+	return lifecycles, rows.Err()
 }
 
 // LoadConfig loads a configuration by name (loads most recent if multiple exist)
 func (s *SQLiteStore) LoadConfig(name string) (*kubernetes.ClusterConfig, error) {
-	var rawData string
+	var id, rawData string
 	err := s.db.QueryRow(`
-		SELECT raw_data FROM configs 
+		SELECT id, raw_data FROM configs 
 		WHERE name = ? 
 		ORDER BY timestamp DESC 
 		LIMIT 1
-	`, name).Scan(&rawData)
+	`, name).Scan(&id, &rawData)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -187,12 +633,7 @@ func (s *SQLiteStore) LoadConfig(name string) (*kubernetes.ClusterConfig, error)
 		return nil, fmt.Errorf("failed to query config: %w", err)
 	}
 	
-	var config kubernetes.ClusterConfig
-	if err := json.Unmarshal([]byte(rawData), &config); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
-	}
-	
-	return &config, nil
+	return s.loadConfigData(id, rawData)
 }
 
 // LoadConfigByID loads a configuration by its unique ID
@@ -209,24 +650,20 @@ func (s *SQLiteStore) LoadConfigByID(id string) (*kubernetes.ClusterConfig, erro
 		return nil, fmt.Errorf("failed to query config: %w", err)
 	}
 	
-	var config kubernetes.ClusterConfig
-	if err := json.Unmarshal([]byte(rawData), &config); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
-	}
-	
-	return &config, nil
+	return s.loadConfigData(id, rawData)
 }
 
 // GetConfigMetadata retrieves metadata for a configuration by ID
 func (s *SQLiteStore) GetConfigMetadata(id string) (*ConfigMetadata, error) {
 	var metadata ConfigMetadata
 	var resourceCountsJSON, tagsJSON string
+	var fingerprintJSON sql.NullString
 	
 	err := s.db.QueryRow(`
-		SELECT id, name, timestamp, resource_counts, tags, description, created_at
+		SELECT id, name, timestamp, resource_counts, tags, description, created_at, fingerprint
 		FROM configs WHERE id = ?
 	`, id).Scan(&metadata.ID, &metadata.Name, &metadata.Timestamp, 
-		&resourceCountsJSON, &tagsJSON, &metadata.Description, &metadata.CreatedAt)
+		&resourceCountsJSON, &tagsJSON, &metadata.Description, &metadata.CreatedAt, &fingerprintJSON)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -246,9 +683,26 @@ func (s *SQLiteStore) GetConfigMetadata(id string) (*ConfigMetadata, error) {
 		}
 	}
 	
+	if metadata.Fingerprint, err = decodeFingerprint(fingerprintJSON); err != nil {
+		return nil, err
+	}
+	
 	return &metadata, nil
 }
 
+// decodeFingerprint decodes a stored fingerprint. Configurations saved before
+// fingerprinting have none.
+func decodeFingerprint(data sql.NullString) (*kubernetes.ClusterFingerprint, error) {
+	if !data.Valid || data.String == "" || data.String == "null" {
+		return nil, nil
+	}
+	var fingerprint kubernetes.ClusterFingerprint
+	if err := json.Unmarshal([]byte(data.String), &fingerprint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fingerprint: %w", err)
+	}
+	return &fingerprint, nil
+}
+
 // ListConfigs returns a list of configuration names (legacy interface)
 func (s *SQLiteStore) ListConfigs() ([]string, error) {
 	rows, err := s.db.Query(`
@@ -274,7 +728,7 @@ func (s *SQLiteStore) ListConfigs() ([]string, error) {
 // GetConfigHistory returns all configurations for a given name, ordered by timestamp
 func (s *SQLiteStore) GetConfigHistory(name string) ([]ConfigMetadata, error) {
 	rows, err := s.db.Query(`
-		SELECT id, name, timestamp, resource_counts, tags, description, created_at
+		SELECT id, name, timestamp, resource_counts, tags, description, created_at, fingerprint
 		FROM configs 
 		WHERE name = ? 
 		ORDER BY timestamp DESC
@@ -288,9 +742,10 @@ func (s *SQLiteStore) GetConfigHistory(name string) ([]ConfigMetadata, error) {
 	for rows.Next() {
 		var metadata ConfigMetadata
 		var resourceCountsJSON, tagsJSON string
+		var fingerprintJSON sql.NullString
 		
 		err := rows.Scan(&metadata.ID, &metadata.Name, &metadata.Timestamp,
-			&resourceCountsJSON, &tagsJSON, &metadata.Description, &metadata.CreatedAt)
+			&resourceCountsJSON, &tagsJSON, &metadata.Description, &metadata.CreatedAt, &fingerprintJSON)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan config metadata: %w", err)
 		}
@@ -306,6 +761,10 @@ func (s *SQLiteStore) GetConfigHistory(name string) ([]ConfigMetadata, error) {
 			}
 		}
 		
+		if metadata.Fingerprint, err = decodeFingerprint(fingerprintJSON); err != nil {
+			return nil, err
+		}
+		
 		history = append(history, metadata)
 	}
 	
@@ -378,39 +837,25 @@ func (s *SQLiteStore) compareSecurityAnalysis(id1, id2 string) (*SecurityDiffere
 		return nil, fmt.Errorf("failed to get security analysis for config %s: %w", id2, err)
 	}
 	
-	return &SecurityDifference{
-		PrivilegedContainers: SecurityFindingDiff{
-			Before: len(analysis1.PrivilegedContainers),
-			After:  len(analysis2.PrivilegedContainers),
-			Change: len(analysis2.PrivilegedContainers) - len(analysis1.PrivilegedContainers),
-		},
-		CapabilityContainers: SecurityFindingDiff{
-			Before: len(analysis1.CapabilityContainers),
-			After:  len(analysis2.CapabilityContainers),
-			Change: len(analysis2.CapabilityContainers) - len(analysis1.CapabilityContainers),
-		},
-		HostNamespaceUsage: SecurityFindingDiff{
-			Before: len(analysis1.HostNamespaceWorkloads),
-			After:  len(analysis2.HostNamespaceWorkloads),
-			Change: len(analysis2.HostNamespaceWorkloads) - len(analysis1.HostNamespaceWorkloads),
-		},
-		HostPathVolumes: SecurityFindingDiff{
-			Before: len(analysis1.HostPathVolumes),
-			After:  len(analysis2.HostPathVolumes),
-			Change: len(analysis2.HostPathVolumes) - len(analysis1.HostPathVolumes),
-		},
-	}, nil
+	securityDiff := newSecurityDifference(analysis1.FindingCounts(), analysis2.FindingCounts())
+	
+	// Analyses stored before findings were recorded only have counts to compare
+	if analysis1.Findings != nil && analysis2.Findings != nil {
+		securityDiff.addFindingChanges(analysis1.Findings, analysis2.Findings)
+	}
+	return &securityDiff, nil
 }
 
 // getSecurityAnalysis retrieves stored security analysis for a configuration
 func (s *SQLiteStore) getSecurityAnalysis(configID string) (*StoredSecurityAnalysis, error) {
 	var privilegedJSON, capabilityJSON, hostNamespaceJSON, hostPathJSON string
+	var findingsJSON, riskJSON sql.NullString
 	
 	err := s.db.QueryRow(`
 		SELECT privileged_containers, capability_containers, 
-			host_namespace_workloads, host_path_volumes
+			host_namespace_workloads, host_path_volumes, findings, risk
 		FROM security_analysis WHERE config_id = ?
-	`, configID).Scan(&privilegedJSON, &capabilityJSON, &hostNamespaceJSON, &hostPathJSON)
+	`, configID).Scan(&privilegedJSON, &capabilityJSON, &hostNamespaceJSON, &hostPathJSON, &findingsJSON, &riskJSON)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -438,6 +883,16 @@ func (s *SQLiteStore) getSecurityAnalysis(configID string) (*StoredSecurityAnaly
 		return nil, fmt.Errorf("failed to unmarshal host path volumes: %w", err)
 	}
 	
+	// Analyses stored by older versions have no findings or risk column value
+	if findingsJSON.Valid && findingsJSON.String != "" {
+		if err := json.Unmarshal([]byte(findingsJSON.String), &analysis.Findings); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal findings: %w", err)
+		}
+	}
+	if err := decodeRisk(riskJSON, analysis); err != nil {
+		return nil, err
+	}
+	
 	return analysis, nil
 }
 
@@ -449,12 +904,25 @@ func (s *SQLiteStore) DeleteConfig(id string) error {
 	}
 	defer tx.Rollback()
 	
+	// The lifecycle of a configuration's findings outlives the cleanup of old
+	// versions and is only dropped with the last one
+	var name string
+	err = tx.QueryRow("SELECT name FROM configs WHERE id = ?", id).Scan(&name)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to query config: %w", err)
+	}
+	
 	// Delete security analysis first (foreign key constraint)
 	_, err = tx.Exec("DELETE FROM security_analysis WHERE config_id = ?", id)
 	if err != nil {
 		return fmt.Errorf("failed to delete security analysis: %w", err)
 	}
 	
+	_, err = tx.Exec("DELETE FROM config_items WHERE config_id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete config items: %w", err)
+	}
+	
 	// Delete configuration
 	result, err := tx.Exec("DELETE FROM configs WHERE id = ?", id)
 	if err != nil {
@@ -470,6 +938,14 @@ func (s *SQLiteStore) DeleteConfig(id string) error {
 		return fmt.Errorf("configuration with ID '%s' not found", id)
 	}
 	
+	_, err = tx.Exec(`
+		DELETE FROM finding_lifecycle
+		WHERE config_name = ? AND NOT EXISTS (SELECT 1 FROM configs WHERE name = ?)
+	`, name, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete finding lifecycle: %w", err)
+	}
+	
 	return tx.Commit()
 }
 
@@ -477,7 +953,7 @@ func (s *SQLiteStore) DeleteConfig(id string) error {
 func (s *SQLiteStore) GetSecurityAnalysisHistory(name string) ([]StoredSecurityAnalysis, error) {
 	rows, err := s.db.Query(`
 		SELECT sa.config_id, sa.privileged_containers, sa.capability_containers, 
-			   sa.host_namespace_workloads, sa.host_path_volumes
+			   sa.host_namespace_workloads, sa.host_path_volumes, sa.findings, sa.risk
 		FROM security_analysis sa
 		JOIN configs c ON sa.config_id = c.id
 		WHERE c.name = ?
@@ -492,9 +968,10 @@ func (s *SQLiteStore) GetSecurityAnalysisHistory(name string) ([]StoredSecurityA
 	for rows.Next() {
 		var analysis StoredSecurityAnalysis
 		var privilegedJSON, capabilityJSON, hostNamespaceJSON, hostPathJSON string
+		var findingsJSON, riskJSON sql.NullString
 
 		err := rows.Scan(&analysis.ConfigID, &privilegedJSON, &capabilityJSON, 
-			&hostNamespaceJSON, &hostPathJSON)
+			&hostNamespaceJSON, &hostPathJSON, &findingsJSON, &riskJSON)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan security analysis: %w", err)
 		}
@@ -516,12 +993,34 @@ func (s *SQLiteStore) GetSecurityAnalysisHistory(name string) ([]StoredSecurityA
 			return nil, fmt.Errorf("failed to unmarshal host path volumes: %w", err)
 		}
 
+		if findingsJSON.Valid && findingsJSON.String != "" {
+			if err := json.Unmarshal([]byte(findingsJSON.String), &analysis.Findings); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal findings: %w", err)
+			}
+		}
+		if err := decodeRisk(riskJSON, &analysis); err != nil {
+			return nil, err
+		}
+
 		history = append(history, analysis)
 	}
 
 	return history, rows.Err()
 }
 
+// decodeRisk reads a stored risk report into an analysis
+func decodeRisk(value sql.NullString, analysis *StoredSecurityAnalysis) error {
+	if !value.Valid || value.String == "" || value.String == "null" {
+		return nil
+	}
+	var risk kubernetes.RiskReport
+	if err := json.Unmarshal([]byte(value.String), &risk); err != nil {
+		return fmt.Errorf("failed to unmarshal risk: %w", err)
+	}
+	analysis.Risk = &risk
+	return nil
+}
+
 // Close closes the database connection
 func (s *SQLiteStore) Close() error {
 	if s.db != nil {
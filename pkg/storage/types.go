@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"fmt"
+	"sort"
 	"time"
 	
 	"github.com/raesene/eolas/pkg/kubernetes"
@@ -15,6 +17,8 @@ type ConfigMetadata struct {
 	ResourceCounts map[string]int    `json:"resource_counts"`
 	Tags           map[string]string `json:"tags,omitempty"`
 	Description    string            `json:"description,omitempty"`
+	// Fingerprint is the cluster's distribution and node software, detected at ingest
+	Fingerprint *kubernetes.ClusterFingerprint `json:"fingerprint,omitempty"`
 }
 
 // ConfigComparison represents the differences between two configurations
@@ -38,6 +42,7 @@ type SecurityDifference struct {
 	CapabilityContainers SecurityFindingDiff `json:"capability_containers"`
 	HostNamespaceUsage   SecurityFindingDiff `json:"host_namespace_usage"`
 	HostPathVolumes      SecurityFindingDiff `json:"host_path_volumes"`
+	Findings             map[string]SecurityFindingDiff `json:"findings,omitempty"` // keyed by analyzer ID
 }
 
 // SecurityFindingDiff represents changes in security findings
@@ -50,6 +55,79 @@ type SecurityFindingDiff struct {
 	Modified  []string `json:"modified,omitempty"`
 }
 
+// FindingLifecycle tracks a finding, identified by its fingerprint, across the
+// versions of a configuration
+type FindingLifecycle struct {
+	Fingerprint string             `json:"fingerprint"`
+	Finding     kubernetes.Finding `json:"finding"`
+	FirstSeen   time.Time          `json:"first_seen"`
+	LastSeen    time.Time          `json:"last_seen"`
+	ResolvedAt  *time.Time         `json:"resolved_at,omitempty"` // first version without the finding, nil while it is open
+}
+
+// Open reports whether the finding is present in the latest version
+func (l FindingLifecycle) Open() bool {
+	return l.ResolvedAt == nil
+}
+
+// Age is how long the finding was present: until it was resolved, or until the
+// latest version it was seen in while it is open
+func (l FindingLifecycle) Age() time.Duration {
+	if l.ResolvedAt != nil {
+		return l.ResolvedAt.Sub(l.FirstSeen)
+	}
+	return l.LastSeen.Sub(l.FirstSeen)
+}
+
+// RemediationStats summarises how long findings took to resolve
+type RemediationStats struct {
+	Severity kubernetes.Severity `json:"severity,omitempty"` // empty for findings of every severity
+	Open     int                 `json:"open"`
+	Resolved int                 `json:"resolved"`
+	Mean     time.Duration       `json:"mean"` // time to resolve
+	Median   time.Duration       `json:"median"`
+	Longest  time.Duration       `json:"longest"`
+}
+
+// SummarizeRemediation returns the remediation stats of all findings followed by
+// those of each severity that has findings, most severe first
+func SummarizeRemediation(lifecycles []FindingLifecycle) []RemediationStats {
+	summarize := func(severity kubernetes.Severity, include func(l FindingLifecycle) bool) RemediationStats {
+		stats := RemediationStats{Severity: severity}
+		var durations []time.Duration
+		var total time.Duration
+		for _, l := range lifecycles {
+			if !include(l) {
+				continue
+			}
+			if l.Open() {
+				stats.Open++
+				continue
+			}
+			stats.Resolved++
+			durations = append(durations, l.Age())
+			total += l.Age()
+		}
+		if len(durations) > 0 {
+			sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+			stats.Mean = total / time.Duration(len(durations))
+			stats.Median = durations[len(durations)/2]
+			stats.Longest = durations[len(durations)-1]
+		}
+		return stats
+	}
+	
+	summary := []RemediationStats{summarize("", func(FindingLifecycle) bool { return true })}
+	for _, severity := range []kubernetes.Severity{kubernetes.SeverityCritical, kubernetes.SeverityHigh,
+		kubernetes.SeverityMedium, kubernetes.SeverityLow, kubernetes.SeverityInfo} {
+		stats := summarize(severity, func(l FindingLifecycle) bool { return l.Finding.Severity == severity })
+		if stats.Open+stats.Resolved > 0 {
+			summary = append(summary, stats)
+		}
+	}
+	return summary
+}
+
 // StoredSecurityAnalysis represents pre-computed security analysis results
 type StoredSecurityAnalysis struct {
 	ConfigID                string                               `json:"config_id"`
@@ -57,4 +135,136 @@ type StoredSecurityAnalysis struct {
 	CapabilityContainers    []kubernetes.CapabilityContainer    `json:"capability_containers"`
 	HostNamespaceWorkloads  []kubernetes.HostNamespaceWorkload  `json:"host_namespace_workloads"`
 	HostPathVolumes         []kubernetes.HostPathVolume         `json:"host_path_volumes"`
+	Findings                map[string][]kubernetes.Finding     `json:"findings,omitempty"` // keyed by analyzer ID
+	Risk                    *kubernetes.RiskReport              `json:"risk,omitempty"`
+}
+
+// RiskReport returns the risk scores of the analysis. They are recomputed from the
+// stored findings, so analyses stored under earlier scoring rules compare fairly
+// with new ones. Analyses stored before findings were recorded use the stored
+// scores, or the built-in analyzers' counts at their default severity.
+func (a StoredSecurityAnalysis) RiskReport() kubernetes.RiskReport {
+	if a.Findings != nil {
+		return kubernetes.ScoreFindings(a.Findings)
+	}
+	if a.Risk != nil {
+		return *a.Risk
+	}
+	
+	var report kubernetes.RiskReport
+	for id, count := range a.FindingCounts() {
+		analyzer, ok := kubernetes.GetAnalyzer(id)
+		if !ok {
+			continue
+		}
+		score := &report.Cluster
+		if kubernetes.IsHygieneAnalyzer(id) {
+			score = &report.Hygiene
+		}
+		score.Score += analyzer.Severity().Weight() * count
+		score.Findings += count
+	}
+	return report
+}
+
+// FindingCounts returns the number of findings per analyzer ID. Analyses stored
+// before findings were recorded only have counts for the built-in analyzers.
+func (a StoredSecurityAnalysis) FindingCounts() map[string]int {
+	counts := make(map[string]int)
+	if a.Findings != nil {
+		for id, findings := range a.Findings {
+			counts[id] = len(findings)
+		}
+		return counts
+	}
+	
+	counts[kubernetes.CheckPrivileged] = len(a.PrivilegedContainers)
+	counts[kubernetes.CheckCapabilities] = len(a.CapabilityContainers)
+	counts[kubernetes.CheckHostNamespaces] = len(a.HostNamespaceWorkloads)
+	counts[kubernetes.CheckHostPath] = len(a.HostPathVolumes)
+	return counts
+}
+
+// TotalFindings returns the number of findings across all analyzers
+func (a StoredSecurityAnalysis) TotalFindings() int {
+	total := 0
+	for _, count := range a.FindingCounts() {
+		total += count
+	}
+	return total
+}
+
+// newSecurityDifference compares finding counts per analyzer, filling in the
+// fixed fields for the built-in analyzers
+func newSecurityDifference(before, after map[string]int) SecurityDifference {
+	diff := SecurityDifference{
+		Findings: make(map[string]SecurityFindingDiff),
+	}
+	
+	ids := make(map[string]bool)
+	for _, id := range kubernetes.AnalyzerIDs() {
+		ids[id] = true
+	}
+	for id := range before {
+		ids[id] = true
+	}
+	for id := range after {
+		ids[id] = true
+	}
+	
+	for id := range ids {
+		diff.Findings[id] = SecurityFindingDiff{
+			Before: before[id],
+			After:  after[id],
+			Change: after[id] - before[id],
+		}
+	}
+	
+	diff.PrivilegedContainers = diff.Findings[kubernetes.CheckPrivileged]
+	diff.CapabilityContainers = diff.Findings[kubernetes.CheckCapabilities]
+	diff.HostNamespaceUsage = diff.Findings[kubernetes.CheckHostNamespaces]
+	diff.HostPathVolumes = diff.Findings[kubernetes.CheckHostPath]
+	
+	return diff
+}
+
+// addFindingChanges lists the findings introduced, resolved and modified between two
+// sets of findings keyed by analyzer ID. Findings are matched by fingerprint, so a
+// finding whose details or severity changed is reported as modified.
+func (d *SecurityDifference) addFindingChanges(before, after map[string][]kubernetes.Finding) {
+	for id, findingDiff := range d.Findings {
+		findingDiff.Added, findingDiff.Removed, findingDiff.Modified = nil, nil, nil
+		
+		previous := make(map[string]kubernetes.Finding)
+		for _, f := range before[id] {
+			previous[f.Fingerprint()] = f
+		}
+		current := make(map[string]bool)
+		for _, f := range after[id] {
+			current[f.Fingerprint()] = true
+		}
+		
+		for _, f := range after[id] {
+			old, ok := previous[f.Fingerprint()]
+			switch {
+			case !ok:
+				findingDiff.Added = append(findingDiff.Added, f.String())
+			case old.Details != f.Details || old.Severity != f.Severity:
+				findingDiff.Modified = append(findingDiff.Modified,
+					fmt.Sprintf("[%s] %s: %s -> %s", f.Severity, f.Subject(), old.Details, f.Details))
+			}
+		}
+		for _, f := range before[id] {
+			if !current[f.Fingerprint()] {
+				findingDiff.Removed = append(findingDiff.Removed, f.String())
+			}
+		}
+		
+		d.Findings[id] = findingDiff
+	}
+	
+	d.PrivilegedContainers = d.Findings[kubernetes.CheckPrivileged]
+	d.CapabilityContainers = d.Findings[kubernetes.CheckCapabilities]
+	d.HostNamespaceUsage = d.Findings[kubernetes.CheckHostNamespaces]
+	d.HostPathVolumes = d.Findings[kubernetes.CheckHostPath]
 }
\ No newline at end of file